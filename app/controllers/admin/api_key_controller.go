@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"data-referensi/app/models"
+	"data-referensi/app/requests"
+	"data-referensi/handlers"
+	"data-referensi/helpers"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func GetAPIKeys(c *fiber.Ctx) error {
+	keys, err := models.APIKeys(c.UserContext())
+	if err != nil {
+		return apiKeyFailed(c, err, helpers.GenerateRM("get", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, keys, helpers.GenerateRM("get", true))
+}
+
+func GetAPIKey(c *fiber.Ctx) error {
+	key, err := models.FindAPIKey(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return apiKeyFailed(c, err, helpers.GenerateRM("get", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, key, helpers.GenerateRM("get", true))
+}
+
+/* Create API Key issues a key, the response is the only place its value appears */
+func CreateAPIKey(c *fiber.Ctx) error {
+	var req requests.APIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	user := c.Locals("user").(models.User)
+	key, err := models.IssueAPIKey(c.UserContext(), req.Name, req.Scopes, req.ExpiresAt, user.ID, c.Locals("permissions").([]string))
+	if err != nil {
+		return apiKeyFailed(c, err, helpers.GenerateRM("insert", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusCreated, key, helpers.GenerateRM("insert", true))
+}
+
+/* Update API Key renames a key and replaces its scopes and expiry */
+func UpdateAPIKey(c *fiber.Ctx) error {
+	var req requests.APIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	key, err := models.UpdateAPIKey(c.UserContext(), c.Params("id"), req.Name, req.Scopes, req.ExpiresAt, c.Locals("permissions").([]string))
+	if err != nil {
+		return apiKeyFailed(c, err, helpers.GenerateRM("update", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, key, helpers.GenerateRM("update", true))
+}
+
+func RevokeAPIKey(c *fiber.Ctx) error {
+	key, err := models.RevokeAPIKey(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return apiKeyFailed(c, err, helpers.GenerateRM("delete", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, key, helpers.GenerateRM("revoke", true))
+}
+
+func apiKeyFailed(c *fiber.Ctx, err error, message string) error {
+	if errors.Is(err, models.ErrScopeNotAllowed) {
+		return handlers.SendFailed(c, fiber.StatusForbidden, nil, err.Error())
+	}
+	return roleFailed(c, err, message)
+}
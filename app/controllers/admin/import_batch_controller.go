@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"data-referensi/app/models"
+	"data-referensi/handlers"
+	"data-referensi/helpers"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+/* Get Import Batches lists the recorded imports newest first, `entity` narrows them to one entity or table */
+func GetImportBatches(c *fiber.Ctx) error {
+	page := max(1, c.QueryInt("page", 1))
+	pageSize := max(1, c.QueryInt("page_size", 10))
+
+	batches, total, err := models.ImportBatches(c.UserContext(), c.Query("entity"), page, pageSize)
+	if err != nil {
+		return batchFailed(c, err, helpers.GenerateRM("get", false))
+	}
+
+	results := map[string]interface{}{
+		"data": batches,
+		"metadata": map[string]interface{}{
+			"page":      page,
+			"per_page":  pageSize,
+			"sub_total": len(batches),
+			"total":     total,
+		},
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
+}
+
+func GetImportBatch(c *fiber.Ctx) error {
+	batch, err := models.FindImportBatch(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return batchFailed(c, err, helpers.GenerateRM("get", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, batch, helpers.GenerateRM("get", true))
+}
+
+/* Revert Import Batch restores the rows a batch updated and soft deletes the rows it inserted */
+func RevertImportBatch(c *fiber.Ctx) error {
+	batch, err := models.RevertImportBatch(c.UserContext(), c.Params("id"))
+	if errors.Is(err, models.ErrBatchReverted) {
+		return handlers.SendFailed(c, fiber.StatusConflict, batch, helpers.GenerateRM("revert", false))
+	}
+	if err != nil {
+		return batchFailed(c, err, err.Error())
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, batch, helpers.GenerateRM("revert", true))
+}
+
+func batchFailed(c *fiber.Ctx, err error, message string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return handlers.SendFailed(c, fiber.StatusNotFound, nil, helpers.GenerateEM(c.Params("id")).Error())
+	}
+	if helpers.CheckTimeout(err) {
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("timeout"))
+	}
+	return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, message)
+}
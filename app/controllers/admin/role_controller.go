@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"data-referensi/app/models"
+	"data-referensi/app/requests"
+	"data-referensi/handlers"
+	"data-referensi/helpers"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func GetRoles(c *fiber.Ctx) error {
+	roles, err := models.Roles(c.UserContext())
+	if err != nil {
+		return roleFailed(c, err, helpers.GenerateRM("get", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, roles, helpers.GenerateRM("get", true))
+}
+
+func GetRole(c *fiber.Ctx) error {
+	role, err := models.FindRole(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return roleFailed(c, err, helpers.GenerateRM("get", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, role, helpers.GenerateRM("get", true))
+}
+
+func CreateRole(c *fiber.Ctx) error {
+	var req requests.RoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	role, err := models.SaveRole(c.UserContext(), "", req.Name, req.Description, req.Permissions, c.Locals("permissions").([]string))
+	if err != nil {
+		return roleFailed(c, err, helpers.GenerateRM("insert", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusCreated, role, helpers.GenerateRM("insert", true))
+}
+
+/* Update Role replaces the name, description and permissions of a role */
+func UpdateRole(c *fiber.Ctx) error {
+	var req requests.RoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	role, err := models.SaveRole(c.UserContext(), c.Params("id"), req.Name, req.Description, req.Permissions, c.Locals("permissions").([]string))
+	if err != nil {
+		return roleFailed(c, err, helpers.GenerateRM("update", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, role, helpers.GenerateRM("update", true))
+}
+
+func DeleteRole(c *fiber.Ctx) error {
+	if err := models.DeleteRole(c.UserContext(), c.Params("id"), c.Locals("permissions").([]string)); err != nil {
+		return roleFailed(c, err, helpers.GenerateRM("delete", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, nil, helpers.GenerateRM("delete", true))
+}
+
+func GetUsers(c *fiber.Ctx) error {
+	users, err := models.Users(c.UserContext())
+	if err != nil {
+		return roleFailed(c, err, helpers.GenerateRM("get", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, users, helpers.GenerateRM("get", true))
+}
+
+/* Set User Roles replaces the roles of a user, given by name */
+func SetUserRoles(c *fiber.Ctx) error {
+	var req requests.UserRolesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	if err := models.SetUserRoles(c.UserContext(), c.Params("id"), req.Roles, c.Locals("permissions").([]string)); err != nil {
+		return roleFailed(c, err, helpers.GenerateRM("update", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, req, helpers.GenerateRM("update", true))
+}
+
+func roleFailed(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return handlers.SendFailed(c, fiber.StatusNotFound, nil, helpers.GenerateEM(c.Params("id")).Error())
+	case errors.Is(err, models.ErrPermissionNotAllowed):
+		return handlers.SendFailed(c, fiber.StatusForbidden, nil, err.Error())
+	case errors.Is(err, models.ErrUnknownRole), errors.Is(err, models.ErrInvalidPermission):
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	case helpers.CheckDuplicateKey(err):
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
+	case helpers.CheckTimeout(err):
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("timeout"))
+	default:
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, message)
+	}
+}
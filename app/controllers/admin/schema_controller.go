@@ -0,0 +1,15 @@
+package controllers
+
+import (
+	"data-referensi/app/models"
+	"data-referensi/handlers"
+	"data-referensi/helpers"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func GetSchemaReport(c *fiber.Ctx) error {
+	report := models.VerifySchema(c.UserContext())
+
+	return handlers.SendSuccess(c, fiber.StatusOK, report, helpers.GenerateRM("get", true))
+}
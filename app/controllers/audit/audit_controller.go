@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"data-referensi/app/models"
+	"data-referensi/handlers"
+	"data-referensi/helpers"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+/* Get Audit Logs lists the changes to the reference data newest first, narrowed by the filters of the query */
+func GetAuditLogs(c *fiber.Ctx) error {
+	filter, err := auditFilter(c)
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+	page := max(1, c.QueryInt("page", 1))
+	pageSize := max(1, c.QueryInt("page_size", 10))
+
+	logs, total, err := models.AuditLogs(c.UserContext(), filter, page, pageSize)
+	if err != nil {
+		return auditFailed(c, err, helpers.GenerateRM("get", false))
+	}
+
+	results := map[string]interface{}{
+		"data": logs,
+		"metadata": map[string]interface{}{
+			"page":      page,
+			"per_page":  pageSize,
+			"sub_total": len(logs),
+			"total":     total,
+		},
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
+}
+
+/* Export Audit Logs sends the changes matched by the filters of the query as a workbook, a row per changed field */
+func ExportAuditLogs(c *fiber.Ctx) error {
+	filter, err := auditFilter(c)
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	file, err := os.CreateTemp("", "audit-*."+models.ExportFormatXLSX)
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("export", false))
+	}
+	export := &helpers.TemporaryFile{File: file}
+
+	if err := models.ExportAuditLogs(c.UserContext(), filter, file); err != nil {
+		export.Close()
+		return auditFailed(c, err, helpers.GenerateRM("export", false))
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		export.Close()
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("export", false))
+	}
+
+	c.Set("Content-Type", models.ExportContentType(models.ExportFormatXLSX))
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=AuditLog.%s", models.ExportFormatXLSX))
+	return c.SendStream(export)
+}
+
+/* Audit Filter reads the entity, record_id, actor, action, source, batch_id, from and to query parameters */
+func auditFilter(c *fiber.Ctx) (models.AuditFilter, error) {
+	filter := models.AuditFilter{
+		Entity:   c.Query("entity"),
+		RecordID: c.Query("record_id"),
+		Actor:    c.Query("actor"),
+		Action:   c.Query("action"),
+		Source:   c.Query("source"),
+		BatchID:  c.Query("batch_id"),
+	}
+
+	var err error
+	if filter.From, err = auditTime(c.Query("from"), false); err != nil {
+		return filter, fmt.Errorf("invalid from: %v", err)
+	}
+	if filter.To, err = auditTime(c.Query("to"), true); err != nil {
+		return filter, fmt.Errorf("invalid to: %v", err)
+	}
+	return filter, nil
+}
+
+/*
+Audit Time reads a bound of the date range in Unix milliseconds, RFC 3339 or
+as a UTC date, which stands for the end of that day when end is set. The `+`
+of an RFC 3339 offset left unescaped in the query arrives as a space.
+*/
+func auditTime(value string, end bool) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return millis, nil
+	}
+	if at, err := time.Parse(time.RFC3339, strings.Replace(value, " ", "+", 1)); err == nil {
+		return at.UnixMilli(), nil
+	}
+
+	day, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return 0, fmt.Errorf("expected Unix milliseconds, RFC 3339 or YYYY-MM-DD, got %q", value)
+	}
+	if end {
+		return day.AddDate(0, 0, 1).UnixMilli() - 1, nil
+	}
+	return day.UnixMilli(), nil
+}
+
+func auditFailed(c *fiber.Ctx, err error, message string) error {
+	if helpers.CheckTimeout(err) {
+		return handlers.SendFailed(c, fiber.StatusGatewayTimeout, nil, helpers.GenerateRM("timeout"))
+	}
+	return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, message)
+}
@@ -1,203 +0,0 @@
-package controllers
-
-import (
-	"data-referensi/app/models"
-	"data-referensi/app/requests"
-	"data-referensi/handlers"
-	"data-referensi/helpers"
-	"fmt"
-	"log"
-	"os"
-	"strings"
-
-	"github.com/gofiber/fiber/v2"
-)
-
-func GetAlmamaterSizes(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	almamaterSizes, err := models.GetAlmamaterSizes(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	results := map[string]interface{}{
-		"data": almamaterSizes,
-		"metadata": map[string]interface{}{
-			"page":      page,
-			"per_page":  pageSize,
-			"sub_total": len(almamaterSizes),
-			"total":     models.CountAlmamaterSizes(),
-		},
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
-}
-
-func ExportAlmamaterSizes(c *fiber.Ctx) error {
-	fileName := "AlmamaterSizes.xlsx"
-	fileSaveAs := fmt.Sprintf("tmp/exports/%s", fileName)
-
-	if err := models.ExportAlmamaterSizes(c, fileSaveAs); err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("export", false))
-	}
-
-	c.Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
-	return c.SendFile(fileSaveAs, false)
-}
-
-func SearchAlmamaterSizes(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	almamaterSizes, err := models.SearchAlmamaterSizes(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, almamaterSizes, helpers.GenerateRM("get", true))
-}
-
-func GetAlmamaterSize(c *fiber.Ctx) error {
-	id := c.Params("id")
-	almamaterSize, err := models.GetAlmamaterSize(id)
-	if err != nil {
-		return handlers.SendSuccess(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, almamaterSize, helpers.GenerateRM("get", true))
-}
-
-func CreateAlmamaterSize(c *fiber.Ctx) error {
-	var req requests.AlmamaterSizeRequest
-
-	if err := c.BodyParser(&req); err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	/* Check Existing ID */
-	id, err := helpers.EnsureUUID(&models.MstAlmamaterSize{})
-	if err != nil {
-		return err
-	}
-
-	err = models.CreateAlmamaterSize(id, req.Code, req.Size, req.ChestSize, req.ArmLength, req.BodyLength)
-	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	almamaterSize, err := models.GetAlmamaterSize(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, almamaterSize, helpers.GenerateRM("insert", true))
-}
-
-func ImportAlmamaterSizes(c *fiber.Ctx) error {
-	file, err := c.FormFile("file_import")
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	filePath := fmt.Sprintf("./tmp/uploads/%s", file.Filename)
-	if err := c.SaveFile(file, filePath); err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("save", false))
-	}
-
-	if err := models.ImportAlmamaterSizes(filePath); err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	if err := os.Remove(filePath); err != nil {
-		log.Println("Error removing uploaded file:", err)
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, nil, helpers.GenerateRM("import", true))
-}
-
-func UpdateAlmamaterSize(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	var req requests.AlmamaterSizeRequest
-
-	if err := c.BodyParser(&req); err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	err := models.UpdateAlmamaterSize(id, req.Code, req.Size, req.ChestSize, req.ArmLength, req.BodyLength)
-	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	almamaterSize, err := models.GetAlmamaterSize(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, almamaterSize, helpers.GenerateRM("update", true))
-}
-
-func DeleteAlmamaterSize(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	err := models.DeleteAlmamaterSize(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, nil, helpers.GenerateRM("delete", true))
-}
-
-func GetTrashAlmamaterSizes(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	almamaterSizes, err := models.GetTrashAlmamaterSizes(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	results := map[string]interface{}{
-		"data": almamaterSizes,
-		"metadata": map[string]interface{}{
-			"page":      page,
-			"per_page":  pageSize,
-			"sub_total": len(almamaterSizes),
-			"total":     models.CountTrashAlmamaterSizes(),
-		},
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
-}
-
-func RestoreAlmamaterSize(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	err := models.RestoreAlmamaterSize(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, nil, helpers.GenerateRM("restore", true))
-}
@@ -1,203 +0,0 @@
-package controllers
-
-import (
-	"data-referensi/app/models"
-	"data-referensi/app/requests"
-	"data-referensi/handlers"
-	"data-referensi/helpers"
-	"fmt"
-	"log"
-	"os"
-	"strings"
-
-	"github.com/gofiber/fiber/v2"
-)
-
-func GetBanks(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	banks, err := models.GetBanks(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	results := map[string]interface{}{
-		"data": banks,
-		"metadata": map[string]interface{}{
-			"page":      page,
-			"per_page":  pageSize,
-			"sub_total": len(banks),
-			"total":     models.CountBanks(),
-		},
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
-}
-
-func ExportBanks(c *fiber.Ctx) error {
-	fileName := "Banks.xlsx"
-	fileSaveAs := fmt.Sprintf("tmp/exports/%s", fileName)
-
-	if err := models.ExportBanks(c, fileSaveAs); err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("export", false))
-	}
-
-	c.Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
-	return c.SendFile(fileSaveAs, false)
-}
-
-func SearchBanks(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	banks, err := models.SearchBanks(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, banks, helpers.GenerateRM("get", true))
-}
-
-func GetBank(c *fiber.Ctx) error {
-	id := c.Params("id")
-	bank, err := models.GetBank(id)
-	if err != nil {
-		return handlers.SendSuccess(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, bank, helpers.GenerateRM("get", true))
-}
-
-func CreateBank(c *fiber.Ctx) error {
-	var req requests.BankRequest
-
-	if err := c.BodyParser(&req); err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	/* Check Existing ID */
-	id, err := helpers.EnsureUUID(&models.MstBank{})
-	if err != nil {
-		return err
-	}
-
-	err = models.CreateBank(id, req.Code, req.Name)
-	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	bank, err := models.GetBank(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, bank, helpers.GenerateRM("insert", true))
-}
-
-func ImportBanks(c *fiber.Ctx) error {
-	file, err := c.FormFile("file_import")
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	filePath := fmt.Sprintf("./tmp/uploads/%s", file.Filename)
-	if err := c.SaveFile(file, filePath); err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("save", false))
-	}
-
-	if err := models.ImportBanks(filePath); err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	if err := os.Remove(filePath); err != nil {
-		log.Println("Error removing uploaded file:", err)
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, nil, helpers.GenerateRM("import", true))
-}
-
-func UpdateBank(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	var req requests.BankRequest
-
-	if err := c.BodyParser(&req); err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	err := models.UpdateBank(id, req.Code, req.Name)
-	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	bank, err := models.GetBank(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, bank, helpers.GenerateRM("update", true))
-}
-
-func DeleteBank(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	err := models.DeleteBank(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, nil, helpers.GenerateRM("delete", true))
-}
-
-func GetTrashBanks(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	banks, err := models.GetTrashBanks(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	results := map[string]interface{}{
-		"data": banks,
-		"metadata": map[string]interface{}{
-			"page":      page,
-			"per_page":  pageSize,
-			"sub_total": len(banks),
-			"total":     models.CountTrashBanks(),
-		},
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
-}
-
-func RestoreBank(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	err := models.RestoreBank(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, nil, helpers.GenerateRM("restore", true))
-}
@@ -1,203 +0,0 @@
-package controllers
-
-import (
-	"data-referensi/app/models"
-	"data-referensi/app/requests"
-	"data-referensi/handlers"
-	"data-referensi/helpers"
-	"fmt"
-	"log"
-	"os"
-	"strings"
-
-	"github.com/gofiber/fiber/v2"
-)
-
-func GetEthnics(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	ethnics, err := models.GetEthnics(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	results := map[string]interface{}{
-		"data": ethnics,
-		"metadata": map[string]interface{}{
-			"page":      page,
-			"per_page":  pageSize,
-			"sub_total": len(ethnics),
-			"total":     models.CountEthnics(),
-		},
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
-}
-
-func ExportEthnics(c *fiber.Ctx) error {
-	fileName := "Ethnics.xlsx"
-	fileSaveAs := fmt.Sprintf("tmp/exports/%s", fileName)
-
-	if err := models.ExportEthnics(c, fileSaveAs); err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("export", false))
-	}
-
-	c.Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
-	return c.SendFile(fileSaveAs, false)
-}
-
-func SearchEthnics(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	ethnics, err := models.SearchEthnics(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, ethnics, helpers.GenerateRM("get", true))
-}
-
-func GetEthnic(c *fiber.Ctx) error {
-	id := c.Params("id")
-	ethnic, err := models.GetEthnic(id)
-	if err != nil {
-		return handlers.SendSuccess(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, ethnic, helpers.GenerateRM("get", true))
-}
-
-func CreateEthnic(c *fiber.Ctx) error {
-	var req requests.EthnicRequest
-
-	if err := c.BodyParser(&req); err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	/* Check Existing ID */
-	id, err := helpers.EnsureUUID(&models.MstEthnic{})
-	if err != nil {
-		return err
-	}
-
-	err = models.CreateEthnic(id, req.Name, req.RegionOfOrigin)
-	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	ethnic, err := models.GetEthnic(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, ethnic, helpers.GenerateRM("insert", true))
-}
-
-func ImportEthnics(c *fiber.Ctx) error {
-	file, err := c.FormFile("file_import")
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	filePath := fmt.Sprintf("./tmp/uploads/%s", file.Filename)
-	if err := c.SaveFile(file, filePath); err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("save", false))
-	}
-
-	if err := models.ImportEthnics(filePath); err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	if err := os.Remove(filePath); err != nil {
-		log.Println("Error removing uploaded file:", err)
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, nil, helpers.GenerateRM("import", true))
-}
-
-func UpdateEthnic(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	var req requests.EthnicRequest
-
-	if err := c.BodyParser(&req); err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	err := models.UpdateEthnic(id, req.Name, req.RegionOfOrigin)
-	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	ethnic, err := models.GetEthnic(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, ethnic, helpers.GenerateRM("update", true))
-}
-
-func DeleteEthnic(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	err := models.DeleteEthnic(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, nil, helpers.GenerateRM("delete", true))
-}
-
-func GetTrashEthnics(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	ethnics, err := models.GetTrashEthnics(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	results := map[string]interface{}{
-		"data": ethnics,
-		"metadata": map[string]interface{}{
-			"page":      page,
-			"per_page":  pageSize,
-			"sub_total": len(ethnics),
-			"total":     models.CountTrashEthnics(),
-		},
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
-}
-
-func RestoreEthnic(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	err := models.RestoreEthnic(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, nil, helpers.GenerateRM("restore", true))
-}
@@ -1,203 +0,0 @@
-package controllers
-
-import (
-	"data-referensi/app/models"
-	"data-referensi/app/requests"
-	"data-referensi/handlers"
-	"data-referensi/helpers"
-	"fmt"
-	"log"
-	"os"
-	"strings"
-
-	"github.com/gofiber/fiber/v2"
-)
-
-func GetJobs(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	jobs, err := models.GetJobs(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	results := map[string]interface{}{
-		"data": jobs,
-		"metadata": map[string]interface{}{
-			"page":      page,
-			"per_page":  pageSize,
-			"sub_total": len(jobs),
-			"total":     models.CountJobs(),
-		},
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
-}
-
-func ExportJobs(c *fiber.Ctx) error {
-	fileName := "Jobs.xlsx"
-	fileSaveAs := fmt.Sprintf("tmp/exports/%s", fileName)
-
-	if err := models.ExportJobs(c, fileSaveAs); err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("export", false))
-	}
-
-	c.Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
-	return c.SendFile(fileSaveAs, false)
-}
-
-func SearchJobs(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	jobs, err := models.SearchJobs(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, jobs, helpers.GenerateRM("get", true))
-}
-
-func GetJob(c *fiber.Ctx) error {
-	id := c.Params("id")
-	job, err := models.GetJob(id)
-	if err != nil {
-		return handlers.SendSuccess(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, job, helpers.GenerateRM("get", true))
-}
-
-func CreateJob(c *fiber.Ctx) error {
-	var req requests.JobRequest
-
-	if err := c.BodyParser(&req); err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	/* Check Existing ID */
-	id, err := helpers.EnsureUUID(&models.MstJob{})
-	if err != nil {
-		return err
-	}
-
-	err = models.CreateJob(id, req.Code, req.Name, req.Description)
-	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	job, err := models.GetJob(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, job, helpers.GenerateRM("insert", true))
-}
-
-func ImportJobs(c *fiber.Ctx) error {
-	file, err := c.FormFile("file_import")
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	filePath := fmt.Sprintf("./tmp/uploads/%s", file.Filename)
-	if err := c.SaveFile(file, filePath); err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("save", false))
-	}
-
-	if err := models.ImportJobs(filePath); err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	if err := os.Remove(filePath); err != nil {
-		log.Println("Error removing uploaded file:", err)
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, nil, helpers.GenerateRM("import", true))
-}
-
-func UpdateJob(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	var req requests.JobRequest
-
-	if err := c.BodyParser(&req); err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	err := models.UpdateJob(id, req.Code, req.Name, req.Description)
-	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	job, err := models.GetJob(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, job, helpers.GenerateRM("update", true))
-}
-
-func DeleteJob(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	err := models.DeleteJob(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, nil, helpers.GenerateRM("delete", true))
-}
-
-func GetTrashJobs(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	jobs, err := models.GetTrashJobs(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	results := map[string]interface{}{
-		"data": jobs,
-		"metadata": map[string]interface{}{
-			"page":      page,
-			"per_page":  pageSize,
-			"sub_total": len(jobs),
-			"total":     models.CountTrashJobs(),
-		},
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
-}
-
-func RestoreJob(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	err := models.RestoreJob(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, nil, helpers.GenerateRM("restore", true))
-}
@@ -1,203 +0,0 @@
-package controllers
-
-import (
-	"data-referensi/app/models"
-	"data-referensi/app/requests"
-	"data-referensi/handlers"
-	"data-referensi/helpers"
-	"fmt"
-	"log"
-	"os"
-	"strings"
-
-	"github.com/gofiber/fiber/v2"
-)
-
-func GetMarriageStatuses(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	marriageStatuses, err := models.GetMarriageStatuses(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	results := map[string]interface{}{
-		"data": marriageStatuses,
-		"metadata": map[string]interface{}{
-			"page":      page,
-			"per_page":  pageSize,
-			"sub_total": len(marriageStatuses),
-			"total":     models.CountMarriageStatuses(),
-		},
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
-}
-
-func ExportMarriageStatuses(c *fiber.Ctx) error {
-	fileName := "MarriageStatuses.xlsx"
-	fileSaveAs := fmt.Sprintf("tmp/exports/%s", fileName)
-
-	if err := models.ExportMarriageStatuses(c, fileSaveAs); err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("export", false))
-	}
-
-	c.Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
-	return c.SendFile(fileSaveAs, false)
-}
-
-func SearchMarriageStatuses(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	marriageStatuses, err := models.SearchMarriageStatuses(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, marriageStatuses, helpers.GenerateRM("get", true))
-}
-
-func GetMarriageStatus(c *fiber.Ctx) error {
-	id := c.Params("id")
-	marriageStatus, err := models.GetMarriageStatus(id)
-	if err != nil {
-		return handlers.SendSuccess(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, marriageStatus, helpers.GenerateRM("get", true))
-}
-
-func CreateMarriageStatus(c *fiber.Ctx) error {
-	var req requests.MarriageStatusRequest
-
-	if err := c.BodyParser(&req); err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	/* Check Existing ID */
-	id, err := helpers.EnsureUUID(&models.MstMarriageStatus{})
-	if err != nil {
-		return err
-	}
-
-	err = models.CreateMarriageStatus(id, req.Name)
-	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	marriageStatus, err := models.GetMarriageStatus(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, marriageStatus, helpers.GenerateRM("insert", true))
-}
-
-func ImportMarriageStatuses(c *fiber.Ctx) error {
-	file, err := c.FormFile("file_import")
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	filePath := fmt.Sprintf("./tmp/uploads/%s", file.Filename)
-	if err := c.SaveFile(file, filePath); err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("save", false))
-	}
-
-	if err := models.ImportMarriageStatuses(filePath); err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	if err := os.Remove(filePath); err != nil {
-		log.Println("Error removing uploaded file:", err)
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, nil, helpers.GenerateRM("import", true))
-}
-
-func UpdateMarriageStatus(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	var req requests.MarriageStatusRequest
-
-	if err := c.BodyParser(&req); err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	err := models.UpdateMarriageStatus(id, req.Name)
-	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key row") {
-			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
-		}
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	marriageStatus, err := models.GetMarriageStatus(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, marriageStatus, helpers.GenerateRM("update", true))
-}
-
-func DeleteMarriageStatus(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	err := models.DeleteMarriageStatus(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, nil, helpers.GenerateRM("delete", true))
-}
-
-func GetTrashMarriageStatuses(c *fiber.Ctx) error {
-	filter := c.Query("filter", "")
-	sortBy := c.Query("sort_by", "name")
-	sortDirection := c.Query("sort_direction", "asc")
-	page := c.QueryInt("page", 1)
-	pageSize := int64(c.QueryInt("page_size", 10))
-
-	marriageStatuses, err := models.GetTrashMarriageStatuses(filter, sortBy, sortDirection, page, pageSize)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusOK, nil, helpers.GenerateRM("get", false))
-	}
-
-	results := map[string]interface{}{
-		"data": marriageStatuses,
-		"metadata": map[string]interface{}{
-			"page":      page,
-			"per_page":  pageSize,
-			"sub_total": len(marriageStatuses),
-			"total":     models.CountTrashMarriageStatuses(),
-		},
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
-}
-
-func RestoreMarriageStatus(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	err := models.RestoreMarriageStatus(id)
-	if err != nil {
-		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
-	}
-
-	return handlers.SendSuccess(c, fiber.StatusCreated, nil, helpers.GenerateRM("restore", true))
-}
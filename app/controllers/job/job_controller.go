@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"data-referensi/app/jobs"
+	"data-referensi/handlers"
+	"data-referensi/helpers"
+	"fmt"
+	"os"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func GetJob(c *fiber.Ctx) error {
+	job, found := jobs.Find(c.Params("id"))
+	if !found {
+		return handlers.SendFailed(c, fiber.StatusNotFound, nil, helpers.GenerateEM(c.Params("id")).Error())
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, job.Status(), helpers.GenerateRM("get", true))
+}
+
+/* Cancel Job stops a queued or running job, a running import rolls back what it has not committed */
+func CancelJob(c *fiber.Ctx) error {
+	job, found := jobs.Find(c.Params("id"))
+	if !found {
+		return handlers.SendFailed(c, fiber.StatusNotFound, nil, helpers.GenerateEM(c.Params("id")).Error())
+	}
+
+	if !job.Cancel() {
+		return handlers.SendFailed(c, fiber.StatusConflict, job.Status(), helpers.GenerateRM("cancel", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, job.Status(), helpers.GenerateRM("cancel", true))
+}
+
+/* Download Job sends the file of a succeeded export job */
+func DownloadJob(c *fiber.Ctx) error {
+	job, found := jobs.Find(c.Params("id"))
+	if !found {
+		return handlers.SendFailed(c, fiber.StatusNotFound, nil, helpers.GenerateEM(c.Params("id")).Error())
+	}
+
+	path, fileName, mimeType, ok := job.File()
+	if !ok {
+		return handlers.SendFailed(c, fiber.StatusConflict, job.Status(), "The job has no file to download yet")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusGone, nil, err.Error())
+	}
+
+	c.Set("Content-Type", mimeType)
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+	return c.SendStream(file)
+}
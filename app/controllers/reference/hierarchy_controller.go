@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"data-referensi/app/jobs"
+	"data-referensi/app/models"
+	"data-referensi/handlers"
+	"data-referensi/helpers"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+/* Hierarchy Controller exports and imports a chain of entities as one multi-sheet workbook */
+type HierarchyController struct {
+	hierarchy *models.Hierarchy
+}
+
+func NewHierarchy(hierarchy *models.Hierarchy) *HierarchyController {
+	return &HierarchyController{hierarchy: hierarchy}
+}
+
+func (ctl *HierarchyController) Export(c *fiber.Ctx) error {
+	if format := c.Query("format", models.ExportFormatXLSX); format != models.ExportFormatXLSX {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, fmt.Sprintf("invalid format %q, a hierarchy is only exported as xlsx", format))
+	}
+
+	options := models.ExportOptions{
+		Format:    models.ExportFormatXLSX,
+		Query:     listParams(c).Query,
+		Ancestors: c.QueryBool("ancestors"),
+	}
+	options.Query.Filter = ""
+
+	if c.QueryBool("async") {
+		fileName := fmt.Sprintf("%s.xlsx", ctl.hierarchy.Name)
+		return exportJob(c, ctl.hierarchy.Name, fileName, models.ExportFormatXLSX, func(ctx context.Context, w io.Writer, job *jobs.Job) error {
+			options.Progress = func(processed int) { job.Progress(processed, 0) }
+			return ctl.hierarchy.Export(ctx, options, w)
+		})
+	}
+
+	file, err := os.CreateTemp("", "export-*.xlsx")
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("export", false))
+	}
+	export := &helpers.TemporaryFile{File: file}
+
+	if err := ctl.hierarchy.Export(c.UserContext(), options, file); err != nil {
+		export.Close()
+		return failed(c, err, fiber.StatusOK, helpers.GenerateRM("export", false))
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		export.Close()
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("export", false))
+	}
+
+	c.Set("Content-Type", models.ExportContentType(models.ExportFormatXLSX))
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.xlsx", ctl.hierarchy.Name))
+	return c.SendStream(export)
+}
+
+func (ctl *HierarchyController) Import(c *fiber.Ctx) error {
+	options, err := importOptions(c)
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	filePath, uploadErr := saveUpload(c)
+	if uploadErr != nil {
+		return handlers.SendFailed(c, uploadErr.Code, nil, uploadErr.Message)
+	}
+	if c.QueryBool("async") {
+		return importJob(c, ctl.hierarchy.Name, filePath, options, func(ctx context.Context, filePath string, options models.ImportOptions) (interface{}, error) {
+			return ctl.hierarchy.Import(ctx, filePath, options)
+		})
+	}
+	defer removeUpload(filePath)
+
+	results, err := ctl.hierarchy.Import(c.UserContext(), filePath, options)
+	if err != nil {
+		return importFailed(c, err, results)
+	}
+
+	return imported(c, options, results)
+}
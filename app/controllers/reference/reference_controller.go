@@ -0,0 +1,492 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"data-referensi/app/jobs"
+	"data-referensi/app/models"
+	"data-referensi/config"
+	"data-referensi/handlers"
+	"data-referensi/helpers"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+/* Controller exposes the HTTP handlers of a reference entity */
+type Controller[T any, S any, R any] struct {
+	entity *models.Entity[T, S, R]
+}
+
+func New[T any, S any, R any](entity *models.Entity[T, S, R]) *Controller[T, S, R] {
+	return &Controller[T, S, R]{entity: entity}
+}
+
+func (ctl *Controller[T, S, R]) Get(c *fiber.Ctx) error {
+	params := listParams(c)
+
+	rows, err := ctl.entity.Get(c.UserContext(), params)
+	if err != nil {
+		return failed(c, err, fiber.StatusOK, helpers.GenerateRM("get", false))
+	}
+
+	results := map[string]interface{}{
+		"data": rows,
+		"metadata": map[string]interface{}{
+			"page":      params.Page,
+			"per_page":  params.PageSize,
+			"sub_total": len(rows),
+			"total":     ctl.entity.Count(c.UserContext()),
+		},
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
+}
+
+func (ctl *Controller[T, S, R]) Export(c *fiber.Ctx) error {
+	options, status, err := ctl.exportOptions(c)
+	if err != nil {
+		return handlers.SendFailed(c, status, nil, err.Error())
+	}
+	format := options.Format
+
+	if c.QueryBool("async") {
+		fileName := fmt.Sprintf("%s.%s", ctl.entity.Name, format)
+		return exportJob(c, ctl.entity.Name, fileName, format, func(ctx context.Context, w io.Writer, job *jobs.Job) error {
+			total := 0
+			if options.Query.Filter == "" && options.Query.ParentID == "" {
+				if options.Query.Trashed {
+					total = int(ctl.entity.CountTrash(ctx))
+				} else {
+					total = int(ctl.entity.Count(ctx))
+				}
+			}
+			options.Progress = func(processed int) { job.Progress(processed, total) }
+			job.Progress(0, total)
+			return ctl.entity.Export(ctx, options, w)
+		})
+	}
+
+	/* Each request writes its own temporary file, removed once the response is sent */
+	file, err := os.CreateTemp("", "export-*."+format)
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("export", false))
+	}
+	export := &helpers.TemporaryFile{File: file}
+
+	if err := ctl.entity.Export(c.UserContext(), options, file); err != nil {
+		export.Close()
+		return failed(c, err, fiber.StatusOK, helpers.GenerateRM("export", false))
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		export.Close()
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("export", false))
+	}
+
+	c.Set("Content-Type", models.ExportContentType(format))
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.%s", ctl.entity.Name, format))
+	return c.SendStream(export)
+}
+
+/* Import Template sends an empty workbook with the headers, rules and parent dropdowns of the import */
+func (ctl *Controller[T, S, R]) ImportTemplate(c *fiber.Ctx) error {
+	var template bytes.Buffer
+	if err := ctl.entity.ImportTemplate(c.UserContext(), &template); err != nil {
+		return failed(c, err, fiber.StatusInternalServerError, helpers.GenerateRM("export", false))
+	}
+
+	c.Set("Content-Type", models.ExportContentType(models.ExportFormatXLSX))
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s-template.xlsx", ctl.entity.Name))
+	return c.Send(template.Bytes())
+}
+
+func (ctl *Controller[T, S, R]) Search(c *fiber.Ctx) error {
+	params := listParams(c)
+
+	rows, err := ctl.entity.Search(c.UserContext(), params)
+	if err != nil {
+		return failed(c, err, fiber.StatusOK, helpers.GenerateRM("get", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, rows, helpers.GenerateRM("get", true))
+}
+
+func (ctl *Controller[T, S, R]) GetByParent(c *fiber.Ctx) error {
+	parentId := c.Params(ctl.entity.Parent.Column)
+	rows, err := ctl.entity.GetByParent(c.UserContext(), parentId)
+	if err != nil {
+		return handlers.SendSuccess(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, rows, helpers.GenerateRM("get", true))
+}
+
+func (ctl *Controller[T, S, R]) Find(c *fiber.Ctx) error {
+	id := c.Params("id")
+	row, err := ctl.entity.Find(c.UserContext(), id, include(c))
+	if err != nil {
+		return handlers.SendSuccess(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, row, helpers.GenerateRM("get", true))
+}
+
+func (ctl *Controller[T, S, R]) Create(c *fiber.Ctx) error {
+	var req R
+
+	if err := c.BodyParser(&req); err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	/* Check Existing ID */
+	id, err := helpers.EnsureUUID(c.UserContext(), new(T))
+	if err != nil {
+		return err
+	}
+
+	err = ctl.entity.Create(c.UserContext(), id, req)
+	if err != nil {
+		if helpers.CheckDuplicateKey(err) {
+			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
+		}
+		return failed(c, err, fiber.StatusInternalServerError, err.Error())
+	}
+
+	row, err := ctl.entity.Find(c.UserContext(), id, nil)
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusCreated, row, helpers.GenerateRM("insert", true))
+}
+
+func (ctl *Controller[T, S, R]) Import(c *fiber.Ctx) error {
+	options, err := importOptions(c)
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	filePath, uploadErr := saveUpload(c)
+	if uploadErr != nil {
+		return handlers.SendFailed(c, uploadErr.Code, nil, uploadErr.Message)
+	}
+	if c.QueryBool("async") {
+		return importJob(c, ctl.entity.Name, filePath, options, func(ctx context.Context, filePath string, options models.ImportOptions) (interface{}, error) {
+			return ctl.entity.Import(ctx, filePath, options)
+		})
+	}
+	defer removeUpload(filePath)
+
+	result, err := ctl.entity.Import(c.UserContext(), filePath, options)
+	if err != nil {
+		return importFailed(c, err, result)
+	}
+
+	return imported(c, options, result)
+}
+
+func (ctl *Controller[T, S, R]) Update(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req R
+
+	if err := c.BodyParser(&req); err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	err := ctl.entity.Update(c.UserContext(), id, req)
+	if err != nil {
+		if helpers.CheckDuplicateKey(err) {
+			return handlers.SendFailed(c, fiber.StatusBadRequest, nil, helpers.GenerateRM("exist"))
+		}
+		return failed(c, err, fiber.StatusInternalServerError, err.Error())
+	}
+
+	row, err := ctl.entity.Find(c.UserContext(), id, nil)
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusCreated, row, helpers.GenerateRM("update", true))
+}
+
+func (ctl *Controller[T, S, R]) Delete(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	err := ctl.entity.Delete(c.UserContext(), id)
+	if err != nil {
+		return failed(c, err, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusCreated, nil, helpers.GenerateRM("delete", true))
+}
+
+func (ctl *Controller[T, S, R]) GetTrash(c *fiber.Ctx) error {
+	params := listParams(c)
+
+	rows, err := ctl.entity.GetTrash(c.UserContext(), params)
+	if err != nil {
+		return failed(c, err, fiber.StatusOK, helpers.GenerateRM("get", false))
+	}
+
+	results := map[string]interface{}{
+		"data": rows,
+		"metadata": map[string]interface{}{
+			"page":      params.Page,
+			"per_page":  params.PageSize,
+			"sub_total": len(rows),
+			"total":     ctl.entity.CountTrash(c.UserContext()),
+		},
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, results, helpers.GenerateRM("get", true))
+}
+
+func (ctl *Controller[T, S, R]) Restore(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	err := ctl.entity.Restore(c.UserContext(), id)
+	if err != nil {
+		return failed(c, err, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusCreated, nil, helpers.GenerateRM("restore", true))
+}
+
+/* Failed sends a failed response, 404 for a missing record or 504 when the request deadline cut the database call short */
+func failed(c *fiber.Ctx, err error, status int, message string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return handlers.SendFailed(c, fiber.StatusNotFound, nil, helpers.GenerateEM(c.Params("id")).Error())
+	}
+	if helpers.CheckTimeout(err) {
+		return handlers.SendFailed(c, fiber.StatusGatewayTimeout, nil, helpers.GenerateRM("timeout"))
+	}
+	return handlers.SendFailed(c, status, nil, message)
+}
+
+/*
+Export Options reads the format, the list filter and sort, `source=trash` to
+export the trash, the parent column (`province_id` for cities) to export the
+rows of one parent and `ancestors=true` to add the parents' code and name.
+*/
+func (ctl *Controller[T, S, R]) exportOptions(c *fiber.Ctx) (models.ExportOptions, int, error) {
+	options := models.ExportOptions{Query: listParams(c).Query, Ancestors: c.QueryBool("ancestors")}
+
+	switch source := c.Query("source", "active"); source {
+	case "active":
+	case "trash":
+		options.Query.Trashed = true
+	default:
+		return options, fiber.StatusBadRequest, fmt.Errorf("invalid source %q, expected active or trash", source)
+	}
+
+	if ctl.entity.Parent != nil {
+		options.Query.ParentID = c.Query(ctl.entity.Parent.Column)
+	}
+
+	format, status, err := exportFormat(c)
+	options.Format = format
+	return options, status, err
+}
+
+/*
+Export Format reads the `format` parameter, falling back to the Accept header
+and to XLSX when the client accepts anything.
+*/
+func exportFormat(c *fiber.Ctx) (string, int, error) {
+	if format := strings.ToLower(c.Query("format")); format != "" {
+		if models.ExportContentType(format) == "" {
+			return "", fiber.StatusBadRequest, fmt.Errorf("invalid format %q, expected xlsx, csv, json, ndjson or ods", format)
+		}
+		return format, fiber.StatusOK, nil
+	}
+
+	if c.Get(fiber.HeaderAccept) == "" {
+		return models.ExportFormatXLSX, fiber.StatusOK, nil
+	}
+
+	offers := []string{}
+	for _, item := range models.ExportContentTypes {
+		offers = append(offers, item.ContentType)
+	}
+	accepted := c.Accepts(offers...)
+	for _, item := range models.ExportContentTypes {
+		if item.ContentType == accepted {
+			return item.Format, fiber.StatusOK, nil
+		}
+	}
+
+	return "", fiber.StatusNotAcceptable, fmt.Errorf("none of the export media types is acceptable: %s", strings.Join(offers, ", "))
+}
+
+/* List Params reads the filter, sort, pagination and include query parameters */
+func listParams(c *fiber.Ctx) models.ListParams {
+	params := models.ListParams{Include: include(c)}
+	params.Filter = c.Query("filter", "")
+	params.SortBy = c.Query("sort_by", "name")
+	params.SortDirection = c.Query("sort_direction", "asc")
+	params.Page = c.QueryInt("page", 1)
+	params.PageSize = int64(c.QueryInt("page_size", 10))
+
+	return params
+}
+
+/* Save Upload stores the `file_import` form file under a unique name, keeping the extension the format is detected from */
+func saveUpload(c *fiber.Ctx) (string, *fiber.Error) {
+	file, err := c.FormFile("file_import")
+	if err != nil {
+		return "", fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	filePath := fmt.Sprintf("./tmp/uploads/%s%s", helpers.GenerateUUID(), filepath.Ext(file.Filename))
+	if err := c.SaveFile(file, filePath); err != nil {
+		return "", fiber.NewError(fiber.StatusInternalServerError, helpers.GenerateRM("save", false))
+	}
+
+	return filePath, nil
+}
+
+func removeUpload(filePath string) {
+	if err := os.Remove(filePath); err != nil {
+		log.Println("Error removing uploaded file:", err)
+	}
+}
+
+/* Import Failed maps an import error to its response, with the partial result when there is one */
+func importFailed(c *fiber.Ctx, err error, result interface{}) error {
+	if helpers.CheckTimeout(err) {
+		return failed(c, err, fiber.StatusInternalServerError, err.Error())
+	}
+	if errors.Is(err, models.ErrImportFile) {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+	if errors.Is(err, models.ErrImportRejected) {
+		return handlers.SendFailed(c, fiber.StatusUnprocessableEntity, result, err.Error())
+	}
+	if helpers.CheckDuplicateKey(err) {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, result, helpers.GenerateRM("exist"))
+	}
+	return handlers.SendFailed(c, fiber.StatusInternalServerError, result, err.Error())
+}
+
+func imported(c *fiber.Ctx, options models.ImportOptions, result interface{}) error {
+	if options.DryRun {
+		return handlers.SendSuccess(c, fiber.StatusOK, result, helpers.GenerateRM("check", true))
+	}
+	return handlers.SendSuccess(c, fiber.StatusOK, result, helpers.GenerateRM("import", true))
+}
+
+/* Import Options reads the import mode, `all` (default) or `partial`, `dry_run`, `patch` and the name of the uploaded file */
+func importOptions(c *fiber.Ctx) (models.ImportOptions, error) {
+	options := models.ImportOptions{Mode: c.Query("mode", models.ImportModeAll), DryRun: c.QueryBool("dry_run"), Patch: c.QueryBool("patch")}
+	if file, err := c.FormFile("file_import"); err == nil {
+		options.FileName = file.Filename
+	}
+	if options.Mode != models.ImportModeAll && options.Mode != models.ImportModePartial {
+		return options, fmt.Errorf("invalid mode %q, expected %s or %s", options.Mode, models.ImportModeAll, models.ImportModePartial)
+	}
+
+	return options, nil
+}
+
+/*
+Include reads the comma separated relations to embed. Without the parameter
+every relation is embedded, `include=` or `include=none` embeds none.
+*/
+func include(c *fiber.Ctx) []string {
+	if !c.Context().QueryArgs().Has("include") {
+		return nil
+	}
+
+	names := []string{}
+	for _, name := range strings.Split(c.Query("include"), ",") {
+		if name = strings.TrimSpace(name); name != "" && name != "none" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+/*
+Import Job runs an import in the background: the upload moves into the
+folder of the job, removed with it, and the response is 202 with the status.
+The import writes as the actor of the request.
+*/
+func importJob(c *fiber.Ctx, entity string, filePath string, options models.ImportOptions, run func(ctx context.Context, filePath string, options models.ImportOptions) (interface{}, error)) error {
+	var upload string
+	actor, acting := helpers.ActorFrom(c.UserContext())
+	job := jobs.New(jobs.KindImport, entity, config.Timeout(config.OperationImport), func(ctx context.Context, job *jobs.Job) error {
+		if acting {
+			ctx = helpers.WithActor(ctx, actor)
+		}
+		options.Progress = job.Progress
+		result, err := run(ctx, upload, options)
+		job.SetResult(result)
+		return err
+	})
+
+	folder, err := job.Folder()
+	if err == nil {
+		upload = filepath.Join(folder, filepath.Base(filePath))
+		err = os.Rename(filePath, upload)
+	}
+	if err != nil {
+		removeUpload(filePath)
+		jobs.Discard(job)
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("save", false))
+	}
+
+	return enqueue(c, job)
+}
+
+/* Export Job writes an export into the folder of a background job, offered for download once it succeeds */
+func exportJob(c *fiber.Ctx, entity string, fileName string, format string, run func(ctx context.Context, w io.Writer, job *jobs.Job) error) error {
+	job := jobs.New(jobs.KindExport, entity, config.Timeout(config.OperationExport), func(ctx context.Context, job *jobs.Job) error {
+		folder, err := job.Folder()
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(folder, fileName)
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+
+		err = run(ctx, file, job)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+
+		job.SetFile(path, fileName, models.ExportContentType(format))
+		job.SetDownload(fmt.Sprintf("/api/jobs/%s/download", job.Status().ID))
+		return nil
+	})
+
+	return enqueue(c, job)
+}
+
+/* Enqueue binds the job to the caller and to the permission the route required, stored by the permission middlewares */
+func enqueue(c *fiber.Ctx, job *jobs.Job) error {
+	owner := ""
+	if actor, acting := helpers.ActorFrom(c.UserContext()); acting {
+		owner = actor.ID
+	}
+	permission, _ := c.Locals("permission").(string)
+	job.SetAccess(owner, permission)
+
+	if err := jobs.Enqueue(job); err != nil {
+		return handlers.SendFailed(c, fiber.StatusServiceUnavailable, nil, helpers.GenerateRM("queue", false))
+	}
+	return handlers.SendSuccess(c, fiber.StatusAccepted, job.Status(), helpers.GenerateRM("queue", true))
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"data-referensi/app/models"
+	"data-referensi/app/requests"
+	"data-referensi/handlers"
+	"data-referensi/helpers"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func Login(c *fiber.Ctx) error {
+	var req requests.LoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	tokens, err := models.Login(c.UserContext(), req.Username, req.Password)
+	if errors.Is(err, models.ErrInvalidCredentials) {
+		return handlers.SendFailed(c, fiber.StatusUnauthorized, nil, err.Error())
+	}
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("login", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, tokens, helpers.GenerateRM("login", true))
+}
+
+/* Refresh trades a refresh token for a new access and refresh token */
+func Refresh(c *fiber.Ctx) error {
+	var req requests.RefreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	tokens, err := models.Refresh(c.UserContext(), req.RefreshToken)
+	if errors.Is(err, models.ErrInvalidToken) {
+		return handlers.SendFailed(c, fiber.StatusUnauthorized, nil, err.Error())
+	}
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("login", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, tokens, helpers.GenerateRM("login", true))
+}
+
+/* Logout revokes the session of the access token */
+func Logout(c *fiber.Ctx) error {
+	if err := models.Logout(c.UserContext(), c.Locals("session").(string)); err != nil {
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("logout", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, nil, helpers.GenerateRM("logout", true))
+}
+
+/* Me returns the signed in user with the permissions of its roles */
+func Me(c *fiber.Ctx) error {
+	user := c.Locals("user").(models.User)
+	permissions, err := models.UserPermissions(c.UserContext(), user.ID)
+	if err != nil {
+		return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, helpers.GenerateRM("get", false))
+	}
+
+	return handlers.SendSuccess(c, fiber.StatusOK, fiber.Map{"user": user, "permissions": permissions}, helpers.GenerateRM("get", true))
+}
@@ -0,0 +1,288 @@
+package jobs
+
+import (
+	"context"
+	"data-referensi/helpers"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	KindImport = "import"
+	KindExport = "export"
+)
+
+const (
+	StateQueued    = "queued"
+	StateRunning   = "running"
+	StateSucceeded = "succeeded"
+	StateFailed    = "failed"
+	StateCancelled = "cancelled"
+)
+
+/* Err Queue Full is returned by Enqueue when every worker is busy and the queue is full */
+var ErrQueueFull = errors.New("the job queue is full")
+
+/* Folder holds a folder per job, with its upload or its export file */
+const Folder = "tmp/jobs"
+
+/* Task is the work of a job, it reports progress and its result through job and stops when ctx is done */
+type Task func(ctx context.Context, job *Job) error
+
+/* Status is a snapshot of a job, Total is 0 while unknown */
+type Status struct {
+	ID         string      `json:"id"`
+	Kind       string      `json:"kind"`
+	Entity     string      `json:"entity"`
+	State      string      `json:"state"`
+	Processed  int         `json:"processed"`
+	Total      int         `json:"total"`
+	Result     interface{} `json:"result,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	Download   string      `json:"download,omitempty"`
+	CreatedAt  int64       `json:"created_at"`
+	StartedAt  int64       `json:"started_at,omitempty"`
+	FinishedAt int64       `json:"finished_at,omitempty"`
+}
+
+/*
+Job is a background import or export, read by the status endpoint while a
+worker runs it. Owner is the id of the user or API key that created it and
+Permission the one its route required, together they decide who may see,
+download or cancel it.
+*/
+type Job struct {
+	mutex      sync.Mutex
+	status     Status
+	owner      string
+	permission string
+	task       Task
+	timeout    time.Duration
+	cancel     context.CancelFunc
+	cancelled  bool
+	file       string
+	fileName   string
+	mimeType   string
+}
+
+var (
+	registry = map[string]*Job{}
+	mutex    sync.Mutex
+	queue    chan *Job
+)
+
+/*
+Start runs workers goroutines that take jobs from a queue of size pending
+jobs, and drops finished jobs and their files once they are older than
+retention. Folders left by an earlier run are removed, their jobs are gone.
+*/
+func Start(workers int, size int, retention time.Duration) {
+	os.RemoveAll(Folder)
+
+	queue = make(chan *Job, size)
+	for i := 0; i < workers; i++ {
+		go work()
+	}
+
+	go func() {
+		for range time.Tick(time.Minute) {
+			expire(retention)
+		}
+	}()
+}
+
+/* New creates a queued job, not yet run, its folder can receive files before Enqueue */
+func New(kind string, entity string, timeout time.Duration, task Task) *Job {
+	job := &Job{task: task, timeout: timeout, status: Status{
+		ID:        helpers.GenerateUUID(),
+		Kind:      kind,
+		Entity:    entity,
+		State:     StateQueued,
+		CreatedAt: time.Now().UnixMilli(),
+	}}
+
+	mutex.Lock()
+	registry[job.status.ID] = job
+	mutex.Unlock()
+
+	return job
+}
+
+/* Enqueue hands the job to the workers, a job the queue has no room for is dropped */
+func Enqueue(job *Job) error {
+	select {
+	case queue <- job:
+		return nil
+	default:
+		Discard(job)
+		return ErrQueueFull
+	}
+}
+
+/* Find returns the job with id, finished jobs are kept until they expire */
+func Find(id string) (*Job, bool) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	job, found := registry[id]
+	return job, found
+}
+
+/* Status returns a snapshot of the job */
+func (j *Job) Status() Status {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return j.status
+}
+
+/* Folder returns the folder of the job, created on first use */
+func (j *Job) Folder() (string, error) {
+	folder := filepath.Join(Folder, j.status.ID)
+	return folder, os.MkdirAll(folder, os.ModePerm)
+}
+
+/* Set Access binds the job to owner, empty for an anonymous caller, and to the permission its route required */
+func (j *Job) SetAccess(owner string, permission string) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.owner, j.permission = owner, permission
+}
+
+/* Access returns the owner and the permission the job was bound to */
+func (j *Job) Access() (owner string, permission string) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return j.owner, j.permission
+}
+
+/* Progress records how many rows are done out of total, a total of 0 keeps the known one */
+func (j *Job) Progress(processed int, total int) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.status.Processed = processed
+	if total > 0 {
+		j.status.Total = total
+	}
+}
+
+/* Set Result records the result reported once the job is finished, successful or not */
+func (j *Job) SetResult(result interface{}) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.status.Result = result
+}
+
+/* Set File records the file a finished export offers for download, under fileName with mimeType */
+func (j *Job) SetFile(path string, fileName string, mimeType string) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.file, j.fileName, j.mimeType = path, fileName, mimeType
+}
+
+/* File returns the download of a succeeded job, ok is false while there is none */
+func (j *Job) File() (path string, fileName string, mimeType string, ok bool) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return j.file, j.fileName, j.mimeType, j.file != "" && j.status.State == StateSucceeded
+}
+
+/* Set Download records the URL the status points to once the file is ready */
+func (j *Job) SetDownload(url string) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.status.Download = url
+}
+
+/* Cancel stops a queued or running job, ok is false when it already finished */
+func (j *Job) Cancel() bool {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	switch j.status.State {
+	case StateQueued:
+		j.cancelled = true
+		j.finish(StateCancelled, "")
+		return true
+	case StateRunning:
+		j.cancelled = true
+		j.cancel()
+		return true
+	default:
+		return false
+	}
+}
+
+func work() {
+	for job := range queue {
+		job.run()
+	}
+}
+
+func (j *Job) run() {
+	j.mutex.Lock()
+	if j.cancelled {
+		j.mutex.Unlock()
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	j.cancel = cancel
+	j.status.State = StateRunning
+	j.status.StartedAt = time.Now().UnixMilli()
+	j.mutex.Unlock()
+
+	err := j.task(ctx, j)
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	switch {
+	case j.cancelled:
+		j.finish(StateCancelled, "")
+	case helpers.CheckTimeout(err):
+		j.finish(StateFailed, helpers.GenerateRM("timeout"))
+	case err != nil:
+		j.finish(StateFailed, err.Error())
+	default:
+		j.finish(StateSucceeded, "")
+	}
+}
+
+/* Finish ends the job, called with the job locked */
+func (j *Job) finish(state string, message string) {
+	j.status.State = state
+	j.status.Error = message
+	j.status.FinishedAt = time.Now().UnixMilli()
+	if state != StateSucceeded {
+		j.status.Download = ""
+	}
+}
+
+/* Expire drops the jobs that finished more than retention ago, with their folder */
+func expire(retention time.Duration) {
+	mutex.Lock()
+	expired := []*Job{}
+	for _, job := range registry {
+		status := job.Status()
+		if status.FinishedAt > 0 && time.Since(time.UnixMilli(status.FinishedAt)) > retention {
+			expired = append(expired, job)
+		}
+	}
+	mutex.Unlock()
+
+	for _, job := range expired {
+		Discard(job)
+	}
+}
+
+/* Discard forgets a job and removes its folder */
+func Discard(job *Job) {
+	mutex.Lock()
+	delete(registry, job.status.ID)
+	mutex.Unlock()
+
+	os.RemoveAll(filepath.Join(Folder, job.status.ID))
+}
@@ -0,0 +1,228 @@
+package middlewares
+
+import (
+	"data-referensi/app/jobs"
+	"data-referensi/app/models"
+	"data-referensi/config"
+	"data-referensi/handlers"
+	"data-referensi/helpers"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jinzhu/inflection"
+)
+
+/*
+Auth Middleware requires a bearer access token of an open session, whose user
+and session id are stored in the locals `user` and `session`, or an
+`X-API-Key` header with a valid key, stored in the local `api_key`.
+*/
+func AuthMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !authenticate(c) {
+			return handlers.SendFailed(c, fiber.StatusUnauthorized, nil, helpers.GenerateRM("unauthorized"))
+		}
+		return c.Next()
+	}
+}
+
+/* User Middleware requires a signed in user, API keys are refused */
+func UserMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !authenticate(c) {
+			return handlers.SendFailed(c, fiber.StatusUnauthorized, nil, helpers.GenerateRM("unauthorized"))
+		}
+		if _, found := c.Locals("user").(models.User); !found {
+			return handlers.SendFailed(c, fiber.StatusForbidden, nil, helpers.GenerateRM("forbidden")+": only signed in users")
+		}
+		return c.Next()
+	}
+}
+
+/*
+Permission Middleware requires a signed in user or an API key allowed to take
+action on the resource of the route, `<group>.<entity>` taken from its path,
+so a route under /api/region/provinces needs `region.province:<action>`.
+*/
+func PermissionMiddleware(action string) fiber.Handler {
+	return permissionMiddleware(func(c *fiber.Ctx) string {
+		return fmt.Sprintf("%s:%s", resource(c.Route().Path), action)
+	})
+}
+
+/* Resource Permission Middleware is Permission Middleware for a resource named explicitly, for routes whose path names none */
+func ResourcePermissionMiddleware(resource string, action string) fiber.Handler {
+	permission := fmt.Sprintf("%s:%s", resource, action)
+	return permissionMiddleware(func(c *fiber.Ctx) string {
+		return permission
+	})
+}
+
+func permissionMiddleware(permissionOf func(c *fiber.Ctx) string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !authenticate(c) {
+			return handlers.SendFailed(c, fiber.StatusUnauthorized, nil, helpers.GenerateRM("unauthorized"))
+		}
+
+		permissions, err := callerPermissions(c)
+		if err != nil {
+			return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
+		}
+
+		permission := permissionOf(c)
+		if !models.Allowed(permissions, permission) {
+			return handlers.SendFailed(c, fiber.StatusForbidden, nil, helpers.GenerateRM("forbidden")+": "+permission)
+		}
+
+		c.Locals("permission", permission)
+		return c.Next()
+	}
+}
+
+/* Caller Permissions returns the permissions of the roles of the user, or the scopes of the API key */
+func callerPermissions(c *fiber.Ctx) ([]string, error) {
+	if permissions, done := c.Locals("permissions").([]string); done {
+		return permissions, nil
+	}
+
+	var permissions []string
+	if key, found := c.Locals("api_key").(models.APIKey); found {
+		permissions = key.Scopes
+	} else {
+		var err error
+		if permissions, err = models.UserPermissions(c.Context(), c.Locals("user").(models.User).ID); err != nil {
+			return nil, err
+		}
+	}
+
+	c.Locals("permissions", permissions)
+	return permissions, nil
+}
+
+/*
+Read Middleware lets anyone read when PUBLIC_READS is on, and otherwise
+requires the `read` permission. Either way the local `permission` holds the
+read permission of the route, as after Permission Middleware.
+*/
+func ReadMiddleware() fiber.Handler {
+	permission := PermissionMiddleware("read")
+
+	return func(c *fiber.Ctx) error {
+		if config.PublicReads {
+			c.Locals("permission", fmt.Sprintf("%s:read", resource(c.Route().Path)))
+			return c.Next()
+		}
+		return permission(c)
+	}
+}
+
+/*
+Export Middleware guards an export like the rows it reads: `source=trash`
+needs the `trash` permission, as listing the trash does, any other source is
+a read under Read Middleware. It guards the background export jobs too.
+*/
+func ExportMiddleware() fiber.Handler {
+	read := ReadMiddleware()
+	trash := PermissionMiddleware("trash")
+
+	return func(c *fiber.Ctx) error {
+		if c.Query("source") == "trash" {
+			return trash(c)
+		}
+		return read(c)
+	}
+}
+
+/*
+Job Middleware lets the caller that created the job of the route, or one
+holding the permission its route required, see, download or cancel it. While
+PUBLIC_READS is on, anyone may see and download a job created by a read, as
+anyone could run the read. An unknown job is left to the controller.
+*/
+func JobMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		job, found := jobs.Find(c.Params("id"))
+		if !found {
+			return c.Next()
+		}
+
+		owner, permission := job.Access()
+		if config.PublicReads && c.Method() == fiber.MethodGet && strings.HasSuffix(permission, ":read") {
+			return c.Next()
+		}
+
+		if !authenticate(c) {
+			return handlers.SendFailed(c, fiber.StatusUnauthorized, nil, helpers.GenerateRM("unauthorized"))
+		}
+		if actor, acting := helpers.ActorFrom(c.UserContext()); acting && owner != "" && actor.ID == owner {
+			return c.Next()
+		}
+
+		permissions, err := callerPermissions(c)
+		if err != nil {
+			return handlers.SendFailed(c, fiber.StatusInternalServerError, nil, err.Error())
+		}
+		if !models.Allowed(permissions, permission) {
+			return handlers.SendFailed(c, fiber.StatusForbidden, nil, helpers.GenerateRM("forbidden")+": "+permission)
+		}
+
+		return c.Next()
+	}
+}
+
+/*
+Authenticate stores the caller of the bearer token or API key in the locals,
+and as the actor of the user context so the writes it makes record it. False
+when neither is accepted.
+*/
+func authenticate(c *fiber.Ctx) bool {
+	if _, done := c.Locals("user").(models.User); done {
+		return true
+	}
+	if _, done := c.Locals("api_key").(models.APIKey); done {
+		return true
+	}
+
+	if plain := strings.TrimSpace(c.Get("X-API-Key")); plain != "" && c.Get(fiber.HeaderAuthorization) == "" {
+		key, err := models.AuthenticateAPIKey(c.Context(), plain)
+		if err != nil {
+			return false
+		}
+		c.Locals("api_key", key)
+		c.SetUserContext(helpers.WithActor(c.UserContext(), helpers.Actor{ID: key.ID, Kind: helpers.ActorAPIKey, Name: key.Name}))
+		return true
+	}
+
+	scheme, token, found := strings.Cut(c.Get(fiber.HeaderAuthorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return false
+	}
+
+	user, session, err := models.Authenticate(c.Context(), strings.TrimSpace(token))
+	if err != nil {
+		return false
+	}
+
+	c.Locals("user", user)
+	c.Locals("session", session)
+	c.SetUserContext(helpers.WithActor(c.UserContext(), helpers.Actor{ID: user.ID, Kind: helpers.ActorUser, Name: user.Username}))
+	return true
+}
+
+/* Resource names the group and entity of a route path, /api/region/provinces/:id is region.province */
+func resource(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && segments[0] == "api" {
+		segments = segments[1:]
+	}
+
+	switch len(segments) {
+	case 0:
+		return "*"
+	case 1:
+		return segments[0]
+	default:
+		return segments[0] + "." + inflection.Singular(segments[1])
+	}
+}
@@ -10,7 +10,7 @@ import (
 )
 
 func CleanupMiddleware() fiber.Handler {
-	folders := []string{"tmp/uploads", "tmp/exports"}
+	folders := []string{"tmp/uploads"}
 	maxAge := 5 * time.Minute
 
 	return func(c *fiber.Ctx) error {
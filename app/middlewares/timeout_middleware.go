@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"context"
+	"data-referensi/config"
+	"data-referensi/helpers"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+/*
+Timeout Middleware gives the request a user context that expires after the
+deadline of operation. The context derives from the fasthttp request context,
+so it is also cancelled when the server shuts down, and it is cancelled as
+soon as the client resets the connection. Every database call made with
+c.UserContext() is aborted on the server once it fires. The actor set by the
+auth middlewares is carried over.
+*/
+func TimeoutMiddleware(operation string) fiber.Handler {
+	timeout := config.Timeout(operation)
+
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.Context(), timeout)
+		defer cancel()
+		stop := helpers.WatchDisconnect(c.Context().Conn(), cancel)
+		defer stop()
+		if actor, found := helpers.ActorFrom(c.UserContext()); found {
+			ctx = helpers.WithActor(ctx, actor)
+		}
+
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"data-referensi/config"
+	"data-referensi/helpers"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+/* Err Scope Not Allowed is returned when a key would get a scope its issuer does not have */
+var ErrScopeNotAllowed = errors.New("scope not allowed")
+
+/* API Key Prefix starts every key, so leaked keys are easy to recognise */
+const apiKeyPrefix = "drk_"
+
+/* Last Used Interval is how often the last use of a key is written, at most */
+const lastUsedInterval = time.Minute
+
+/*
+API Key lets a service call the API without a user, with the permissions in
+Scopes. Only the hash of the key is stored, Prefix identifies it in lists.
+*/
+type APIKey struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	KeyHash    string   `json:"-"`
+	Scopes     []string `json:"scopes" gorm:"serializer:json"`
+	CreatedAt  int64    `json:"created_at" gorm:"autoCreateTime:false"`
+	CreatedBy  *string  `json:"created_by"`
+	UpdatedAt  int64    `json:"updated_at" gorm:"autoUpdateTime:false"`
+	LastUsedAt *int64   `json:"last_used_at"`
+	ExpiresAt  *int64   `json:"expires_at"`
+	RevokedAt  *int64   `json:"revoked_at"`
+}
+
+/* Issued API Key is a new key, the only time Key is returned in clear */
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+/* API Keys lists every key, newest first */
+func APIKeys(ctx context.Context) ([]APIKey, error) {
+	keys := []APIKey{}
+	err := config.DB.WithContext(ctx).Order("created_at DESC").Order("id").Find(&keys).Error
+	return keys, err
+}
+
+/* Find API Key returns the key with id, gorm.ErrRecordNotFound when there is none */
+func FindAPIKey(ctx context.Context, id string) (APIKey, error) {
+	var key APIKey
+	err := config.DB.WithContext(ctx).Where("id = ?", id).Take(&key).Error
+	return key, err
+}
+
+/*
+Issue API Key creates a key named name with scopes, each one covered by the
+permissions of the issuer so nobody hands out more than they hold.
+*/
+func IssueAPIKey(ctx context.Context, name string, scopes []string, expiresAt *int64, issuer string, permissions []string) (IssuedAPIKey, error) {
+	if err := checkScopes(scopes, permissions); err != nil {
+		return IssuedAPIKey{}, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return IssuedAPIKey{}, err
+	}
+	plain := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	now := time.Now().UnixMilli()
+	key := APIKey{
+		ID:        helpers.GenerateUUID(),
+		Name:      strings.TrimSpace(name),
+		Prefix:    plain[:len(apiKeyPrefix)+6],
+		KeyHash:   tokenHash(plain),
+		Scopes:    scopes,
+		CreatedAt: now,
+		CreatedBy: &issuer,
+		UpdatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+	if err := config.DB.WithContext(ctx).Create(&key).Error; err != nil {
+		return IssuedAPIKey{}, err
+	}
+	return IssuedAPIKey{APIKey: key, Key: plain}, nil
+}
+
+/* Update API Key renames a key and replaces its scopes and expiry, a revoked key stays revoked */
+func UpdateAPIKey(ctx context.Context, id string, name string, scopes []string, expiresAt *int64, permissions []string) (APIKey, error) {
+	if err := checkScopes(scopes, permissions); err != nil {
+		return APIKey{}, err
+	}
+
+	key, err := FindAPIKey(ctx, id)
+	if err != nil {
+		return key, err
+	}
+
+	key.Name, key.Scopes, key.ExpiresAt, key.UpdatedAt = strings.TrimSpace(name), scopes, expiresAt, time.Now().UnixMilli()
+	err = config.DB.WithContext(ctx).Model(&key).Select("name", "scopes", "expires_at", "updated_at").Updates(&key).Error
+	return key, err
+}
+
+/* Revoke API Key stops a key for good */
+func RevokeAPIKey(ctx context.Context, id string) (APIKey, error) {
+	key, err := FindAPIKey(ctx, id)
+	if err != nil || key.RevokedAt != nil {
+		return key, err
+	}
+
+	now := time.Now().UnixMilli()
+	key.RevokedAt = &now
+	err = config.DB.WithContext(ctx).Model(&key).Update("revoked_at", now).Error
+	return key, err
+}
+
+/*
+Authenticate API Key returns the key that is neither revoked nor expired,
+ErrInvalidToken otherwise, and records its use at most once a minute.
+*/
+func AuthenticateAPIKey(ctx context.Context, plain string) (APIKey, error) {
+	var key APIKey
+	if !strings.HasPrefix(plain, apiKeyPrefix) {
+		return key, ErrInvalidToken
+	}
+
+	db := config.DB.WithContext(ctx)
+	now := time.Now()
+	err := db.Where("key_hash = ? AND revoked_at IS NULL", tokenHash(plain)).Take(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return key, ErrInvalidToken
+	}
+	if err != nil {
+		return key, err
+	}
+	if key.ExpiresAt != nil && *key.ExpiresAt <= now.UnixMilli() {
+		return key, ErrInvalidToken
+	}
+
+	if key.LastUsedAt == nil || now.Sub(time.UnixMilli(*key.LastUsedAt)) >= lastUsedInterval {
+		used := now.UnixMilli()
+		key.LastUsedAt = &used
+		err = db.Model(&APIKey{}).Where("id = ?", key.ID).Update("last_used_at", used).Error
+	}
+	return key, err
+}
+
+func checkScopes(scopes []string, permissions []string) error {
+	if len(scopes) == 0 {
+		return fmt.Errorf("%w: a key needs at least one scope", ErrInvalidPermission)
+	}
+
+	for _, scope := range scopes {
+		if err := CheckPermission(scope); err != nil {
+			return err
+		}
+		if !Allowed(permissions, scope) {
+			return fmt.Errorf("%w: %s is beyond your own permissions", ErrScopeNotAllowed, scope)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package models
+
+import (
+	"context"
+	"data-referensi/app/repositories"
+	"data-referensi/config"
+	"data-referensi/helpers"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+/* Audit Actions are the changes an audit entry records */
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+)
+
+/* Audit Sources tell where a change came from: the API, an import batch or the revert of one */
+const (
+	AuditSourceAPI    = "api"
+	AuditSourceImport = "import"
+	AuditSourceRevert = "revert"
+)
+
+/*
+Audit Log is a change to a record of an entity. Changes maps every field the
+change wrote to its value before and after, a create has no value before and
+a delete or restore changes no field. BatchID is the import batch of an
+import or a revert.
+*/
+type AuditLog struct {
+	ID        string       `json:"id"`
+	Entity    string       `json:"entity"`
+	Table     string       `json:"table_name" gorm:"column:table_name"`
+	RecordID  string       `json:"record_id"`
+	Action    string       `json:"action"`
+	Source    string       `json:"source"`
+	BatchID   *string      `json:"batch_id"`
+	ActorID   *string      `json:"actor_id"`
+	ActorKind *string      `json:"actor_kind"`
+	ActorName *string      `json:"actor_name"`
+	Changes   AuditChanges `json:"changes" gorm:"serializer:json"`
+	CreatedAt int64        `json:"created_at" gorm:"autoCreateTime:false"`
+}
+
+/* Audit Change is the value of a field before and after a change */
+type AuditChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+/* Audit Changes maps a column to its change */
+type AuditChanges map[string]AuditChange
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+/*
+Audit Filter narrows the audit log: Entity matches the name or the table,
+Actor the id or the name of the user or API key, From and To bound the time
+of the change in Unix milliseconds, both included. Empty fields match all.
+*/
+type AuditFilter struct {
+	Entity   string
+	RecordID string
+	Actor    string
+	Action   string
+	Source   string
+	BatchID  string
+	From     int64
+	To       int64
+}
+
+/* Audit Logs lists the entries matching filter, newest first */
+func AuditLogs(ctx context.Context, filter AuditFilter, page int, pageSize int) ([]AuditLog, int64, error) {
+	query := filter.apply(config.DB.WithContext(ctx).Model(&AuditLog{}))
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	logs := []AuditLog{}
+	err := query.Order("created_at DESC").Order("id").Offset((page - 1) * pageSize).Limit(pageSize).Find(&logs).Error
+	return logs, total, err
+}
+
+/* Audit Export Headers are the columns of the audit export, one row per changed field */
+var auditExportHeaders = []string{"Time", "Entity", "Table", "Record ID", "Action", "Source", "Batch ID", "Actor", "Actor Type", "Actor ID", "Field", "Before", "After"}
+
+/*
+Export Audit Logs writes the entries matching filter into w as a workbook,
+newest first, reading them exportChunk at a time. An entry takes a row per
+changed field, a delete or restore a single row without a field.
+*/
+func ExportAuditLogs(ctx context.Context, filter AuditFilter, w io.Writer) error {
+	writer, err := newExportWriter(ExportFormatXLSX, w, nil)
+	if err != nil {
+		return err
+	}
+
+	started := false
+	err = func() error {
+		for page := 1; ; page++ {
+			logs := []AuditLog{}
+			query := filter.apply(config.DB.WithContext(ctx).Model(&AuditLog{}))
+			err := query.Order("created_at DESC").Order("id").Offset((page - 1) * exportChunk).Limit(exportChunk).Find(&logs).Error
+			if err != nil {
+				return err
+			}
+
+			rows := [][]interface{}{}
+			for _, log := range logs {
+				rows = append(rows, log.exportRows()...)
+			}
+			if !started && len(rows) > 0 {
+				started = true
+				if err := writer.WriteHeader(auditExportHeaders, rows); err != nil {
+					return err
+				}
+			}
+			if err := writer.WriteRows(rows); err != nil {
+				return err
+			}
+
+			if len(logs) < exportChunk {
+				return nil
+			}
+		}
+	}()
+	if err == nil && !started {
+		err = writer.WriteHeader(auditExportHeaders, nil)
+	}
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export the audit log: %w", err)
+	}
+	return nil
+}
+
+func (f AuditFilter) apply(query *gorm.DB) *gorm.DB {
+	if f.Entity != "" {
+		query = query.Where("table_name = ? OR LOWER(entity) = ?", f.Entity, strings.ToLower(f.Entity))
+	}
+	if f.RecordID != "" {
+		query = query.Where("record_id = ?", f.RecordID)
+	}
+	if f.Actor != "" {
+		query = query.Where("actor_id = ? OR LOWER(actor_name) = ?", f.Actor, strings.ToLower(f.Actor))
+	}
+	if f.Action != "" {
+		query = query.Where("action = ?", strings.ToLower(f.Action))
+	}
+	if f.Source != "" {
+		query = query.Where("source = ?", strings.ToLower(f.Source))
+	}
+	if f.BatchID != "" {
+		query = query.Where("batch_id = ?", f.BatchID)
+	}
+	if f.From > 0 {
+		query = query.Where("created_at >= ?", f.From)
+	}
+	if f.To > 0 {
+		query = query.Where("created_at <= ?", f.To)
+	}
+	return query
+}
+
+func (l AuditLog) exportRows() [][]interface{} {
+	at := time.UnixMilli(l.CreatedAt).UTC().Format("2006-01-02 15:04:05.000")
+	entry := []interface{}{at, l.Entity, l.Table, l.RecordID, l.Action, l.Source, auditText(l.BatchID), auditText(l.ActorName), auditText(l.ActorKind), auditText(l.ActorID)}
+	if len(l.Changes) == 0 {
+		return [][]interface{}{append(entry, "", "", "")}
+	}
+
+	columns := make([]string, 0, len(l.Changes))
+	for column := range l.Changes {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	rows := make([][]interface{}, 0, len(columns))
+	for _, column := range columns {
+		change := l.Changes[column]
+		rows = append(rows, append(append([]interface{}{}, entry...), column, auditValue(change.From), auditValue(change.To)))
+	}
+	return rows
+}
+
+func auditText(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func auditValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+/*
+Audit Trail collects the entries of one write to an entity, made by the
+actor of the context it was started with, and saves them with the write.
+*/
+type auditTrail struct {
+	entity  string
+	table   string
+	source  string
+	batchID *string
+	actor   helpers.Actor
+	acting  bool
+	logs    []AuditLog
+}
+
+func newAuditTrail(ctx context.Context, entity string, table string, source string, batchID *string) *auditTrail {
+	actor, acting := helpers.ActorFrom(ctx)
+	return &auditTrail{entity: entity, table: table, source: source, batchID: batchID, actor: actor, acting: acting}
+}
+
+/* Add records action on the record id, with the fields whose value differs between before and after */
+func (t *auditTrail) add(id string, action string, before repositories.Values, after repositories.Values) {
+	log := AuditLog{
+		ID:        helpers.GenerateUUID(),
+		Entity:    t.entity,
+		Table:     t.table,
+		RecordID:  id,
+		Action:    action,
+		Source:    t.source,
+		BatchID:   t.batchID,
+		Changes:   auditChanges(before, after),
+		CreatedAt: time.Now().UnixMilli(),
+	}
+	if t.acting {
+		log.ActorID, log.ActorKind, log.ActorName = &t.actor.ID, &t.actor.Kind, &t.actor.Name
+	}
+	t.logs = append(t.logs, log)
+}
+
+/* Save stores the entries in batches small enough for the parameter limit of SQL Server */
+func (t *auditTrail) save(db *gorm.DB) error {
+	if len(t.logs) == 0 {
+		return nil
+	}
+	return db.CreateInBatches(t.logs, repositories.BatchSize(reflect.TypeOf(AuditLog{}).NumField())).Error
+}
+
+func auditChanges(before repositories.Values, after repositories.Values) AuditChanges {
+	changes := AuditChanges{}
+	for column, value := range after {
+		previous, found := before[column]
+		if !found || fmt.Sprint(previous) != fmt.Sprint(value) {
+			changes[column] = AuditChange{From: previous, To: value}
+		}
+	}
+	return changes
+}
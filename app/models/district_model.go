@@ -1,14 +1,9 @@
 package models
 
 import (
-	"data-referensi/config"
-	"data-referensi/helpers"
-	"fmt"
-	"time"
+	"data-referensi/app/requests"
 
-	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
-	"github.com/xuri/excelize/v2"
 )
 
 type MstDistrict struct {
@@ -18,14 +13,11 @@ type MstDistrict struct {
 	Name      string           `json:"name"`
 	Code      string           `json:"code"`
 	CreatedAt int64            `json:"created_at"`
+	CreatedBy *string          `json:"created_by"`
 	UpdatedAt int64            `json:"updated_at"`
-}
-
-type MstDistrictExport struct {
-	ID     uuid.UUID `json:"id"`
-	CityId string    `json:"city_id"`
-	Name   string    `json:"name"`
-	Code   string    `json:"code"`
+	UpdatedBy *string          `json:"updated_by"`
+	DeletedAt *int64           `json:"deleted_at,omitempty"`
+	DeletedBy *string          `json:"deleted_by,omitempty"`
 }
 
 type MstDistrictSearch struct {
@@ -40,380 +32,18 @@ type MstDistrictRelation struct {
 	Code string    `json:"code"`
 }
 
-/* Action */
-func GetDistricts(filter string, sortBy string, sortDirection string, page int, pageSize int64) ([]MstDistrict, error) {
-	return QueryGetDistricts("sp_mst_districts_get", filter, sortBy, sortDirection, page, pageSize)
-}
-
-func ExportDistricts(c *fiber.Ctx, fileSaveAs string) error {
-	districts, err := QueryExportDistricts()
-	if err != nil {
-		return fmt.Errorf("failed to get districts: %v", err)
-	}
-
-	file := excelize.NewFile()
-	sheetName := "Sheet1"
-	file.NewSheet(sheetName)
-
-	columns := []string{"A", "B", "C", "D"}
-	headers := []string{"ID", "City ID", "Name", "Code"}
-
-	for i, col := range columns {
-		cell := fmt.Sprintf("%s1", col)
-		file.SetCellValue(sheetName, cell, headers[i])
-	}
-
-	for i, city := range districts {
-		row := i + 2
-
-		values := []interface{}{
-			city.ID,
-			city.CityId,
-			city.Name,
-			city.Code,
-		}
-
-		for i, col := range columns {
-			cell := fmt.Sprintf("%s%d", col, row)
-			file.SetCellValue(sheetName, cell, values[i])
-		}
-
-	}
-
-	for _, col := range columns {
-		helpers.ExcelAutoSizeColumn(file, sheetName, col, len(districts))
-	}
-
-	if err := file.SaveAs(fileSaveAs); err != nil {
-		return fmt.Errorf("failed to save XLSX file: %v", err)
-	}
-
-	return nil
-}
-
-func SearchDistricts(filter string, sortBy string, sortDirection string, page int, pageSize int64) ([]MstDistrictSearch, error) {
-	return QuerySearchDistricts("sp_mst_districts_get", filter, sortBy, sortDirection, page, pageSize)
-}
-
-func GetDistrictByCityId(city_id string) ([]MstDistrictSearch, error) {
-	return QueryGetDistrictByCityId(city_id)
-}
-
-func GetDistrict(id string) (MstDistrict, error) {
-	return QueryGetDistrict(id)
-}
-
-func CreateDistrict(id string, city_id string, name string, code string) error {
-	return QueryInsertDistrict(id, city_id, name, code)
-}
-
-func ImportDistricts(filePath string) error {
-	file, err := excelize.OpenFile(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to open excel file: %v", err)
-	}
-
-	sheetName := "Sheet1"
-	rows, err := file.GetRows(sheetName)
-	if err != nil {
-		return fmt.Errorf("failed to get rows: %v", err)
-	}
-
-	for i, row := range rows {
-		if i == 0 {
-			continue
-		}
-
-		var id string = ""
-		var city_id string = ""
-		var name string = ""
-		var code string = ""
-
-		if len(row) > 0 && row[0] != "" {
-			id = row[0]
-		}
-		if len(row) > 1 && row[1] != "" {
-			city_id = row[1]
-		}
-		if len(row) > 2 && row[2] != "" {
-			name = row[2]
-		}
-		if len(row) > 3 && row[3] != "" {
-			code = row[3]
-		}
-		if id != "" {
-			exist, err := helpers.CheckModelIDExist(id, &MstDistrict{})
-			if err != nil {
-				return err
-			}
-			if exist {
-				if err := QueryUpdateDistrict(id, city_id, name, code); err != nil {
-					return err
-				}
-			} else {
-				if err := QueryInsertDistrict(id, city_id, name, code); err != nil {
-					return err
-				}
-			}
-		} else {
-			id, err := helpers.EnsureUUID(&MstDistrict{})
-			if err != nil {
-				return err
-			}
-			if err := QueryInsertDistrict(id, city_id, name, code); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-func UpdateDistrict(id string, city_id string, name string, code string) error {
-	return QueryUpdateDistrict(id, city_id, name, code)
-}
-
-func DeleteDistrict(id string) error {
-	if err := QueryDeleteDistrict(id); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func GetTrashDistricts(filter string, sortBy string, sortDirection string, page int, pageSize int64) ([]MstDistrict, error) {
-	return QueryGetDistricts("sp_mst_districts_has_deleted", filter, sortBy, sortDirection, page, pageSize)
-}
-
-func RestoreDistrict(id string) error {
-	if err := QueryRestoreDistrict(id); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-/* Count */
-func CountDistricts() int64 {
-	return helpers.CountModelSize(&MstDistrict{}, true)
-}
-
-func CountTrashDistricts() int64 {
-	return helpers.CountModelSize(&MstDistrict{}, false)
-}
-
-/* Query */
-func QueryGetDistricts(sp string, filter string, sortBy string, sortDirection string, page int, pageSize int64) ([]MstDistrict, error) {
-	db := config.DB
-	var districts []MstDistrict
-
-	query := fmt.Sprintf(`
-        EXEC %s 
-        @Filter = ?, 
-        @SortBy = ?, 
-        @SortDirection = ?, 
-        @Page = ?, 
-        @PageSize = ?
-    `, sp)
-
-	err := db.Raw(query, filter, sortBy, sortDirection, page, pageSize).Scan(&districts).Error
-	if err != nil {
-		return nil, err
-	}
-
-	for i := range districts {
-		city, err := QueryGetCityRelation(districts[i].CityId)
-		if err != nil {
-			return []MstDistrict{}, err
-		}
-
-		districts[i].City = &city
-	}
-
-	return districts, nil
-}
-
-func QueryExportDistricts() ([]MstDistrictExport, error) {
-	db := config.DB
-	var districts []MstDistrictExport
-
-	query := `
-        EXEC sp_mst_districts_get 
-        @Filter = ?, 
-        @SortBy = ?, 
-        @SortDirection = ?, 
-        @Page = ?, 
-        @PageSize = ?
-    `
-
-	err := db.Raw(query, "", "name", "asc", 1, CountDistricts()).Scan(&districts).Error
-	if err != nil {
-		return nil, err
-	}
-
-	return districts, nil
-}
-
-func QuerySearchDistricts(sp string, filter string, sortBy string, sortDirection string, page int, pageSize int64) ([]MstDistrictSearch, error) {
-	db := config.DB
-	var districts []MstDistrictSearch
-
-	query := fmt.Sprintf(`
-        EXEC %s 
-        @Filter = ?, 
-        @SortBy = ?, 
-        @SortDirection = ?, 
-        @Page = ?, 
-        @PageSize = ?
-    `, sp)
-
-	err := db.Raw(query, filter, sortBy, sortDirection, page, pageSize).Scan(&districts).Error
-	if err != nil {
-		return nil, err
-	}
-
-	return districts, nil
-}
-
-func QueryGetDistrictByCityId(city_id string) ([]MstDistrictSearch, error) {
-	db := config.DB
-	var districts []MstDistrictSearch
-
-	query := `
-		EXEC sp_mst_districts_get_by_city_id
-		@city_id = ?
-	`
-	err := db.Raw(query, city_id).Scan(&districts).Error
-	if err != nil {
-		return []MstDistrictSearch{}, err
-	}
-
-	return districts, nil
-}
-
-func QueryGetDistrict(id string) (MstDistrict, error) {
-	db := config.DB
-	var district MstDistrict
-
-	query := `
-		EXEC sp_mst_districts_get_by_id
-		@id = ?
-	`
-	err := db.Raw(query, id).Scan(&district).Error
-	if err != nil {
-		return MstDistrict{}, err
-	}
-
-	city, err := QueryGetCityRelation(district.CityId)
-	if err != nil {
-		return MstDistrict{}, err
-	}
-
-	district.City = &city
-
-	return district, nil
-}
-
-func QueryGetDistrictRelation(id string) (MstDistrictRelation, error) {
-	db := config.DB
-	var city MstDistrictRelation
-
-	query := `
-		EXEC sp_mst_districts_get_by_id
-		@id = ?
-	`
-	result := db.Raw(query, id).Scan(&city)
-	if result.Error != nil {
-		return MstDistrictRelation{}, result.Error
-	}
-
-	return city, nil
-}
-
-func QueryInsertDistrict(id string, city_id string, name string, code string) error {
-	db := config.DB
-	now := time.Now()
-	created_at := now.UnixMilli()
-	updated_at := now.UnixMilli()
-	var created_by, updated_by *string = nil, nil
-
-	query := `
-		EXEC sp_mst_districts_insert
-		@id = ?,
-		@city_id = ?,
-		@name = ?,
-		@code = ?,
-		@created_at = ?,
-		@created_by = ?,
-		@updated_at = ?,
-		@updated_by = ?
-	`
-
-	err := db.Exec(query, id, city_id, name, code, created_at, created_by, updated_at, updated_by).Error
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func QueryUpdateDistrict(id string, city_id string, name string, code string) error {
-	db := config.DB
-
-	now := time.Now()
-	updated_at := now.UnixMilli()
-	var updated_by *string = nil
-
-	query := `
-		EXEC sp_mst_districts_update
-		@id = ?,
-		@city_id = ?,
-		@name = ?,
-		@code = ?,
-		@updated_at = ?,
-		@updated_by = ?
-	`
-
-	err := db.Exec(query, id, city_id, name, code, updated_at, updated_by).Error
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func QueryDeleteDistrict(id string) error {
-	db := config.DB
-
-	now := time.Now()
-	deleted_at := now.UnixMilli()
-	var deleted_by *string = nil
-
-	query := `
-		EXEC sp_mst_districts_delete
-		@id = ?,
-		@deleted_at = ?,
-		@deleted_by = ?
-	`
-
-	err := db.Exec(query, id, deleted_at, deleted_by).Error
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func QueryRestoreDistrict(id string) error {
-	db := config.DB
-	query := `
-		EXEC sp_mst_districts_restore
-		@id = ?
-	`
-
-	err := db.Exec(query, id).Error
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
+var Districts = Register(&Entity[MstDistrict, MstDistrictSearch, requests.DistrictRequest]{
+	Name:      "Districts",
+	Table:     "mst_districts",
+	Procedure: "sp_mst_districts",
+	Fields: []Field{
+		{Column: "city_id", Header: "City ID"},
+		{Column: "name", Header: "Name"},
+		{Column: "code", Header: "Code"},
+	},
+	Unique: []string{"code"},
+	Parent: &Parent{Route: "by-city", Column: "city_id", Procedure: "sp_mst_districts_get_by_city_id"},
+	Relations: []Relation{
+		{Name: "city", Column: "city_id", Entity: Cities},
+	},
+})
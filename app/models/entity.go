@@ -0,0 +1,571 @@
+package models
+
+import (
+	"context"
+	"data-referensi/app/repositories"
+	"data-referensi/config"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+/*
+Entity describes a reference table. T is the row returned by list, detail
+and trash, S is the lightweight row returned by search and by-parent lookups
+and R is the request body (with its validate tags) accepted by create, update
+and import. The columns of S other than id are the ones matched by filter.
+Unique lists the columns of the natural key, unique among untrashed rows.
+*/
+type Entity[T any, S any, R any] struct {
+	Name      string
+	Table     string
+	Procedure string
+	Fields    []Field
+	Unique    []string
+	Parent    *Parent
+	Relations []Relation
+}
+
+/* Field is a writable column, in the order it is exported, Aliases are extra headers accepted on import */
+type Field struct {
+	Column  string
+	Header  string
+	Aliases []string
+}
+
+/* Parent describes the by-<parent> lookup of an entity */
+type Parent struct {
+	Route     string
+	Column    string
+	Procedure string
+	Param     string
+}
+
+/* Relation embeds the parent record referenced by Column into the Name field of a row */
+type Relation struct {
+	Name   string
+	Column string
+	Entity Referable
+}
+
+/* List Params holds the list query and the relations to embed, a nil Include embeds every relation */
+type ListParams struct {
+	repositories.Query
+	Include []string
+}
+
+/*
+Export Options selects the rows of an export: Query filters and sorts them,
+scopes them to a parent and reads the trash instead when Trashed is set.
+Paging is ignored, every matching row is exported. Ancestors adds the code
+and name of every related record, up the whole chain of parents. Progress,
+when set, is told how many rows were written after every chunk.
+*/
+type ExportOptions struct {
+	Format    string
+	Query     repositories.Query
+	Ancestors bool
+	Progress  func(processed int)
+}
+
+/* Export Chunk is the number of rows read per query while exporting */
+const exportChunk = 1000
+
+/* Referable is implemented by every entity so it can be the target of a relation */
+type Referable interface {
+	repository(ctx context.Context) repositories.Repository
+	definition() repositories.Definition
+	relations() []Relation
+	storedRecords(repository repositories.Repository, ids []string) (map[string]repositories.Values, error)
+}
+
+/* Entities holds every registered entity, in declaration order */
+var Entities []Referable
+
+/* Register adds an entity to Entities and returns it, so declarations stay a single expression */
+func Register[T any, S any, R any](entity *Entity[T, S, R]) *Entity[T, S, R] {
+	Entities = append(Entities, entity)
+	return entity
+}
+
+/*
+Tables holds the models of every other table the migrations create, so the
+schema check covers them too. A migration adding a table adds its model here.
+*/
+var Tables = []interface{}{
+	&ImportBatch{}, &ImportBatchRow{},
+	&User{}, &UserSession{},
+	&Role{}, &RolePermission{}, &UserRole{},
+	&APIKey{},
+	&AuditLog{},
+}
+
+/* Verify Schema checks the database against the tables and procedures every entity needs and the tables of Tables */
+func VerifySchema(ctx context.Context) repositories.SchemaReport {
+	definitions := []repositories.Definition{}
+	for _, entity := range Entities {
+		definitions = append(definitions, entity.definition())
+	}
+	return repositories.VerifySchema(config.DB.WithContext(ctx), definitions, tableRequirements())
+}
+
+/* Table Requirements reads the table and columns of every model of Tables, a model gorm cannot parse is reported as a missing table */
+func tableRequirements() []repositories.TableRequirement {
+	requirements := []repositories.TableRequirement{}
+	for _, model := range Tables {
+		parsed, err := schema.Parse(model, &sync.Map{}, config.DB.NamingStrategy)
+		if err != nil {
+			requirements = append(requirements, repositories.TableRequirement{Name: fmt.Sprintf("%T", model)})
+			continue
+		}
+		requirements = append(requirements, repositories.TableRequirement{Name: parsed.Table, Columns: parsed.DBNames})
+	}
+	return requirements
+}
+
+/* Action */
+func (e *Entity[T, S, R]) Get(ctx context.Context, params ListParams) ([]T, error) {
+	params.Trashed = false
+	return e.queryGet(ctx, params)
+}
+
+/*
+Export encodes the rows matched by the options into w, reading them
+exportChunk at a time so memory stays bounded whatever the table size.
+*/
+func (e *Entity[T, S, R]) Export(ctx context.Context, options ExportOptions, w io.Writer) error {
+	columns, _ := e.exportColumns(options)
+	writer, err := newExportWriter(options.Format, w, columns)
+	if err != nil {
+		return err
+	}
+
+	err = e.exportTo(ctx, writer, options)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export %s: %w", strings.ToLower(e.Name), err)
+	}
+
+	return nil
+}
+
+func (e *Entity[T, S, R]) exportTo(ctx context.Context, writer exportWriter, options ExportOptions) error {
+	_, headers := e.exportColumns(options)
+	ancestors := []ancestor{}
+	if options.Ancestors {
+		ancestors = e.ancestors()
+	}
+
+	started := false
+	processed := 0
+	err := e.exportChunks(ctx, options.Query, func(rows []T) error {
+		values := make([][]interface{}, len(rows))
+		for i := range rows {
+			values[i] = e.rowValues(&rows[i])
+		}
+
+		if len(ancestors) > 0 {
+			records := make([]map[string]interface{}, len(values))
+			for i := range values {
+				records[i] = map[string]interface{}{}
+				for j, column := range e.columns() {
+					records[i][column] = values[i][j]
+				}
+			}
+
+			labels, err := resolveAncestors(ctx, records, ancestors)
+			if err != nil {
+				return err
+			}
+			for i := range values {
+				values[i] = append(values[i], labels[i]...)
+			}
+		}
+
+		if !started {
+			started = true
+			if err := writer.WriteHeader(headers, values); err != nil {
+				return err
+			}
+		}
+		if err := writer.WriteRows(values); err != nil {
+			return err
+		}
+
+		processed += len(values)
+		if options.Progress != nil {
+			options.Progress(processed)
+		}
+		return nil
+	})
+	if err == nil && !started {
+		err = writer.WriteHeader(headers, nil)
+	}
+
+	return err
+}
+
+/* Export Columns returns the column names and headers of an export, with the ancestor labels when asked */
+func (e *Entity[T, S, R]) exportColumns(options ExportOptions) ([]string, []string) {
+	columns, headers := e.columns(), e.headers()
+	if options.Ancestors {
+		for _, item := range e.ancestors() {
+			columns = append(columns, item.Key())
+			headers = append(headers, item.Header())
+		}
+	}
+	return columns, headers
+}
+
+func (e *Entity[T, S, R]) Search(ctx context.Context, params ListParams) ([]S, error) {
+	var rows []S
+
+	params.Trashed = false
+	if err := e.repository(ctx).Get(&rows, params.Query); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (e *Entity[T, S, R]) GetByParent(ctx context.Context, parentId string) ([]S, error) {
+	var rows []S
+
+	if e.Parent == nil {
+		return nil, fmt.Errorf("%s has no parent lookup", strings.ToLower(e.Name))
+	}
+
+	if err := e.repository(ctx).GetByParent(&rows, parentId); err != nil {
+		return []S{}, err
+	}
+
+	return rows, nil
+}
+
+func (e *Entity[T, S, R]) Find(ctx context.Context, id string, include []string) (T, error) {
+	var row T
+
+	if err := e.repository(ctx).Find(&row, id); err != nil {
+		return row, err
+	}
+
+	rows := []T{row}
+	if err := e.loadRelations(ctx, rows, include); err != nil {
+		var empty T
+		return empty, err
+	}
+
+	return rows[0], nil
+}
+
+func (e *Entity[T, S, R]) Create(ctx context.Context, id string, req R) error {
+	values := e.requestValues(&req)
+	return e.write(ctx, id, AuditActionCreate, values, func(repository repositories.Repository) error {
+		return repository.Insert(id, values)
+	})
+}
+
+func (e *Entity[T, S, R]) Update(ctx context.Context, id string, req R) error {
+	values := e.requestValues(&req)
+	return e.write(ctx, id, AuditActionUpdate, values, func(repository repositories.Repository) error {
+		return repository.Update(id, values)
+	})
+}
+
+func (e *Entity[T, S, R]) Delete(ctx context.Context, id string) error {
+	return e.write(ctx, id, AuditActionDelete, nil, func(repository repositories.Repository) error {
+		return repository.Delete(id)
+	})
+}
+
+func (e *Entity[T, S, R]) GetTrash(ctx context.Context, params ListParams) ([]T, error) {
+	params.Trashed = true
+	return e.queryGet(ctx, params)
+}
+
+func (e *Entity[T, S, R]) Restore(ctx context.Context, id string) error {
+	return e.write(ctx, id, AuditActionRestore, nil, func(repository repositories.Repository) error {
+		return repository.Restore(id)
+	})
+}
+
+/*
+Write runs a write to the record id in a transaction with its audit entry.
+An update is audited with the fields it changed, a create with every field.
+The record to update, delete or restore must exist, gorm.ErrRecordNotFound
+otherwise, and a delete of a trashed record or a restore of an active one
+changes nothing and is not audited.
+*/
+func (e *Entity[T, S, R]) write(ctx context.Context, id string, action string, values repositories.Values, write func(repository repositories.Repository) error) error {
+	return config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repository := repositories.New(tx, e.definition())
+
+		var before repositories.Values
+		audited := true
+		if action != AuditActionCreate {
+			stored, err := e.storedRecords(repository, []string{id})
+			if err != nil {
+				return err
+			}
+			found := false
+			if before, found = stored[strings.ToLower(id)]; !found {
+				return gorm.ErrRecordNotFound
+			}
+			if action != AuditActionUpdate {
+				before = nil
+				trashed, err := e.trashed(tx, id)
+				if err != nil {
+					return err
+				}
+				audited = trashed == (action == AuditActionRestore)
+			}
+		}
+
+		if err := write(repository); err != nil {
+			return err
+		}
+		if !audited {
+			return nil
+		}
+		trail := newAuditTrail(ctx, e.Name, e.Table, AuditSourceAPI, nil)
+		trail.add(id, action, before, values)
+		return trail.save(tx)
+	})
+}
+
+/* Trashed reports whether the record id is in the trash */
+func (e *Entity[T, S, R]) trashed(db *gorm.DB, id string) (bool, error) {
+	var count int64
+	err := db.Table(e.Table).Where("id = ? AND deleted_at IS NOT NULL", id).Count(&count).Error
+	return count > 0, err
+}
+
+/* Count */
+func (e *Entity[T, S, R]) Count(ctx context.Context) int64 {
+	return e.repository(ctx).Count(false)
+}
+
+func (e *Entity[T, S, R]) CountTrash(ctx context.Context) int64 {
+	return e.repository(ctx).Count(true)
+}
+
+/* Repository returns the storage backend of the entity, bound to ctx */
+func (e *Entity[T, S, R]) repository(ctx context.Context) repositories.Repository {
+	return repositories.New(config.DB.WithContext(ctx), e.definition())
+}
+
+func (e *Entity[T, S, R]) sheetName() string {
+	return e.Name
+}
+
+func (e *Entity[T, S, R]) relations() []Relation {
+	return e.Relations
+}
+
+func (e *Entity[T, S, R]) definition() repositories.Definition {
+	definition := repositories.Definition{
+		Table:     e.Table,
+		Procedure: e.Procedure,
+	}
+
+	for _, field := range e.Fields {
+		definition.Fields = append(definition.Fields, field.Column)
+	}
+
+	search := reflect.TypeOf(new(S)).Elem()
+	for i := 0; i < search.NumField(); i++ {
+		if column := jsonName(search.Field(i)); column != "id" {
+			definition.Search = append(definition.Search, column)
+		}
+	}
+
+	if e.Parent != nil {
+		definition.Parent = &repositories.ParentDefinition{
+			Column:    e.Parent.Column,
+			Procedure: e.Parent.Procedure,
+			Param:     e.Parent.param(),
+		}
+	}
+
+	return definition
+}
+
+/* Query */
+func (e *Entity[T, S, R]) queryGet(ctx context.Context, params ListParams) ([]T, error) {
+	var rows []T
+
+	if err := e.repository(ctx).Get(&rows, params.Query); err != nil {
+		return nil, err
+	}
+
+	if err := e.loadRelations(ctx, rows, params.Include); err != nil {
+		return []T{}, err
+	}
+
+	return rows, nil
+}
+
+/* Export Chunks reads the rows matched by query and hands them to fn exportChunk at a time */
+func (e *Entity[T, S, R]) exportChunks(ctx context.Context, query repositories.Query, fn func(rows []T) error) error {
+	query.PageSize = exportChunk
+
+	for query.Page = 1; ; query.Page++ {
+		var rows []T
+		if err := e.repository(ctx).Get(&rows, query); err != nil {
+			return err
+		}
+
+		if len(rows) > 0 {
+			if err := fn(rows); err != nil {
+				return err
+			}
+		}
+		if int64(len(rows)) < exportChunk {
+			return nil
+		}
+	}
+}
+
+/* Load Relations resolves each included relation of rows with a single lookup per relation */
+func (e *Entity[T, S, R]) loadRelations(ctx context.Context, rows []T, include []string) error {
+	for _, relation := range e.Relations {
+		if !relation.included(include) || len(rows) == 0 {
+			continue
+		}
+
+		ids := []string{}
+		seen := map[string]bool{}
+		for i := range rows {
+			key := fieldByJSON(reflect.ValueOf(&rows[i]).Elem(), relation.Column)
+			if key.IsValid() && key.String() != "" && !seen[key.String()] {
+				seen[key.String()] = true
+				ids = append(ids, key.String())
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		target := fieldByJSON(reflect.ValueOf(&rows[0]).Elem(), relation.Name)
+		if !target.IsValid() {
+			continue
+		}
+
+		parents := reflect.New(reflect.SliceOf(target.Type().Elem()))
+		if err := relation.Entity.repository(ctx).FindMany(parents.Interface(), ids); err != nil {
+			return err
+		}
+
+		byId := map[string]reflect.Value{}
+		for i := 0; i < parents.Elem().Len(); i++ {
+			parent := parents.Elem().Index(i).Addr()
+			byId[strings.ToLower(fmt.Sprint(fieldByJSON(parent.Elem(), "id").Interface()))] = parent
+		}
+
+		for i := range rows {
+			row := reflect.ValueOf(&rows[i]).Elem()
+			if parent, ok := byId[strings.ToLower(fieldByJSON(row, relation.Column).String())]; ok {
+				fieldByJSON(row, relation.Name).Set(parent)
+			}
+		}
+	}
+
+	return nil
+}
+
+/* Included reports whether the relation is listed in include, a nil include lists every relation */
+func (r Relation) included(include []string) bool {
+	if include == nil {
+		return true
+	}
+	for _, name := range include {
+		if name == r.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Parent) param() string {
+	if p.Param != "" {
+		return p.Param
+	}
+	return p.Column
+}
+
+/* Values */
+func (e *Entity[T, S, R]) headers() []string {
+	headers := []string{"ID"}
+	for _, field := range e.Fields {
+		headers = append(headers, field.Header)
+	}
+	return headers
+}
+
+/* Columns are the column names of the exported values, used as JSON keys */
+func (e *Entity[T, S, R]) columns() []string {
+	columns := []string{"id"}
+	for _, field := range e.Fields {
+		columns = append(columns, field.Column)
+	}
+	return columns
+}
+
+func (e *Entity[T, S, R]) rowValues(row *T) []interface{} {
+	value := reflect.ValueOf(row).Elem()
+
+	values := []interface{}{fmt.Sprint(fieldByJSON(value, "id").Interface())}
+	for _, field := range e.Fields {
+		values = append(values, fieldByJSON(value, field.Column).Interface())
+	}
+	return values
+}
+
+func (e *Entity[T, S, R]) requestValues(req *R) repositories.Values {
+	value := reflect.ValueOf(req).Elem()
+
+	values := repositories.Values{}
+	for _, field := range e.Fields {
+		values[field.Column] = fieldByJSON(value, field.Column).Interface()
+	}
+	return values
+}
+
+func (e *Entity[T, S, R]) setRequestValues(req *R, cells []string) {
+	value := reflect.ValueOf(req).Elem()
+
+	for i, field := range e.Fields {
+		if target := fieldByJSON(value, field.Column); target.IsValid() && i < len(cells) {
+			target.SetString(cells[i])
+		}
+	}
+}
+
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
+
+/* Field By JSON returns the struct field whose json tag matches name */
+func fieldByJSON(value reflect.Value, name string) reflect.Value {
+	for i := 0; i < value.NumField(); i++ {
+		if jsonName(value.Type().Field(i)) == name {
+			return value.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+func jsonName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
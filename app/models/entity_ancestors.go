@@ -0,0 +1,135 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+/* Ancestor is a code or name column of a related record, reached from the row through Path */
+type ancestor struct {
+	Path   []Relation
+	Column string
+}
+
+/* Label Columns are the columns of a related record worth showing next to its id */
+var labelColumns = []string{"code", "name"}
+
+/* Ancestors lists the label columns of every relation of the entity and, recursively, of theirs */
+func (e *Entity[T, S, R]) ancestors() []ancestor {
+	return ancestorsOf(e.Relations, nil)
+}
+
+func ancestorsOf(relations []Relation, path []Relation) []ancestor {
+	list := []ancestor{}
+	for _, relation := range relations {
+		next := append(append([]Relation{}, path...), relation)
+
+		fields := map[string]bool{}
+		for _, field := range relation.Entity.definition().Fields {
+			fields[field] = true
+		}
+		for _, column := range labelColumns {
+			if fields[column] {
+				list = append(list, ancestor{Path: next, Column: column})
+			}
+		}
+
+		list = append(list, ancestorsOf(relation.Entity.relations(), next)...)
+	}
+	return list
+}
+
+/* Key is the column name of the ancestor, e.g. province_code */
+func (a ancestor) Key() string {
+	return a.Path[len(a.Path)-1].Name + "_" + a.Column
+}
+
+/* Header is the export header of the ancestor, e.g. Province Code */
+func (a ancestor) Header() string {
+	return title(a.Path[len(a.Path)-1].Name) + " " + title(a.Column)
+}
+
+func (a ancestor) pathKey(depth int) string {
+	names := []string{}
+	for _, relation := range a.Path[:depth] {
+		names = append(names, relation.Name)
+	}
+	return strings.Join(names, ".")
+}
+
+/*
+Resolve Ancestors returns the ancestor values of every row, aligned with
+list. Every relation path is resolved with one lookup for the whole chunk.
+*/
+func resolveAncestors(ctx context.Context, rows []map[string]interface{}, list []ancestor) ([][]interface{}, error) {
+	records := map[string][]map[string]interface{}{"": rows}
+
+	for _, item := range list {
+		for depth := 1; depth <= len(item.Path); depth++ {
+			key := item.pathKey(depth)
+			if _, done := records[key]; done {
+				continue
+			}
+
+			relation := item.Path[depth-1]
+			children := records[item.pathKey(depth-1)]
+
+			ids := []string{}
+			seen := map[string]bool{}
+			for _, child := range children {
+				if id := recordString(child, relation.Column); id != "" && !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+			}
+
+			byId := map[string]map[string]interface{}{}
+			if len(ids) > 0 {
+				var parents []map[string]interface{}
+				if err := relation.Entity.repository(ctx).FindMany(&parents, ids); err != nil {
+					return nil, err
+				}
+				for _, parent := range parents {
+					byId[strings.ToLower(recordString(parent, "id"))] = parent
+				}
+			}
+
+			resolved := make([]map[string]interface{}, len(children))
+			for i, child := range children {
+				resolved[i] = byId[strings.ToLower(recordString(child, relation.Column))]
+			}
+			records[key] = resolved
+		}
+	}
+
+	values := make([][]interface{}, len(rows))
+	for i := range rows {
+		values[i] = make([]interface{}, len(list))
+		for j, item := range list {
+			values[i][j] = recordString(records[item.pathKey(len(item.Path))][i], item.Column)
+		}
+	}
+	return values, nil
+}
+
+func recordString(record map[string]interface{}, column string) string {
+	if record == nil || record[column] == nil {
+		return ""
+	}
+	if value, ok := record[column].([]byte); ok {
+		return string(value)
+	}
+	return fmt.Sprint(record[column])
+}
+
+/* Title turns a column name like educational_level into Educational Level */
+func title(name string) string {
+	words := strings.Split(name, "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
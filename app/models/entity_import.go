@@ -0,0 +1,927 @@
+package models
+
+import (
+	"context"
+	"data-referensi/app/repositories"
+	"data-referensi/app/requests"
+	"data-referensi/config"
+	"data-referensi/helpers"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	/* Import Mode All imports every row in one transaction, rolled back on the first failing row */
+	ImportModeAll = "all"
+	/* Import Mode Partial commits every row on its own and reports the failing ones */
+	ImportModePartial = "partial"
+)
+
+const (
+	ImportActionInsert    = "insert"
+	ImportActionUpdate    = "update"
+	ImportActionUnchanged = "unchanged"
+	ImportActionReject    = "reject"
+)
+
+/* Err Import Rejected is returned when a row fails validation in an all-or-nothing import */
+var ErrImportRejected = errors.New("import rejected")
+
+/* Err Import File is returned when the file cannot be read or lacks a required column */
+var ErrImportFile = errors.New("invalid import file")
+
+/* Header Aliases are extra headers accepted for a column by every entity, on top of Field.Aliases */
+var HeaderAliases = map[string][]string{
+	"name":        {"Nama"},
+	"code":        {"Kode"},
+	"region_code": {"Kode Wilayah"},
+}
+
+/*
+Import Options tunes how a file is imported, a dry run only reports what would
+happen. Progress, when set, is told how many rows are done as the import goes.
+Pending holds, by table, the rows an earlier sheet of the same workbook would
+write, so a dry run accepts them as parents. FileName is the name of the
+uploaded file, recorded with the import batch. Patch keeps the stored value of
+the blank cells of existing records.
+*/
+type ImportOptions struct {
+	Mode     string
+	DryRun   bool
+	Patch    bool
+	FileName string
+	Progress func(processed int, total int)
+	pending  map[string]*pendingRows
+}
+
+/*
+Import Result summarizes an import. Errors lists the rows that were not
+imported, Rows reports the action of every row and is only set on a dry run.
+DurationMs and RowsPerSecond measure the whole import of the table, reading
+the file aside. BatchID is the batch that recorded the import, to revert it.
+*/
+type ImportResult struct {
+	Mode          string            `json:"mode"`
+	DryRun        bool              `json:"dry_run"`
+	Patch         bool              `json:"patch"`
+	Total         int               `json:"total"`
+	Inserted      int               `json:"inserted"`
+	Updated       int               `json:"updated"`
+	Unchanged     int               `json:"unchanged"`
+	Failed        int               `json:"failed"`
+	BatchID       string            `json:"batch_id,omitempty"`
+	DurationMs    int64             `json:"duration_ms"`
+	RowsPerSecond float64           `json:"rows_per_second"`
+	Errors        []ImportError     `json:"errors"`
+	Rows          []ImportRowResult `json:"rows,omitempty"`
+	rows          []importRow
+}
+
+/* Import Error is a rejected row, Row is its line in the file, or its position in a JSON array */
+type ImportError struct {
+	Row     int               `json:"row"`
+	ID      string            `json:"id"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+/* Import Row Result is the action a dry run found for a row, with the reasons it is rejected */
+type ImportRowResult struct {
+	Row    int               `json:"row"`
+	ID     string            `json:"id"`
+	Action string            `json:"action"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+/* Import Row is a parsed line of an import file, Refs holds the code and name columns of each relation */
+type importRow struct {
+	Row    int
+	ID     string
+	Values repositories.Values
+	Refs   map[string]map[string]string
+	Action string
+	Errors map[string]string
+}
+
+func (e *Entity[T, S, R]) Import(ctx context.Context, filePath string, options ImportOptions) (ImportResult, error) {
+	table, err := readImportTable(filePath)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	return e.importTable(config.DB.WithContext(ctx), table, options)
+}
+
+/* Lookup Chunk bounds the ids looked up at once */
+const lookupChunk = 1000
+
+/* Import Chunk is the number of rows written per bulk upsert */
+const importChunk = 1000
+
+/* Import Table imports the records of a table read from a file or a workbook sheet */
+func (e *Entity[T, S, R]) importTable(db *gorm.DB, table importTable, options ImportOptions) (ImportResult, error) {
+	started := time.Now()
+	result, err := e.importRows(db, table, options)
+	result.measure(time.Since(started))
+	return result, err
+}
+
+/*
+Import Rows plans the rows, then writes the inserted and updated ones with a
+bulk upsert. In partial mode a bulk write the database refuses is retried row
+by row, each in its own transaction, to tell the failing rows apart. Every
+written row is recorded in the batch of the import, with its previous values.
+*/
+func (e *Entity[T, S, R]) importRows(db *gorm.DB, table importTable, options ImportOptions) (ImportResult, error) {
+	rows, err := e.mapRows(table, options.Patch)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	if options.Mode == "" {
+		options.Mode = ImportModeAll
+	}
+	result := ImportResult{Mode: options.Mode, DryRun: options.DryRun, Patch: options.Patch, Total: len(rows), Errors: []ImportError{}}
+	options.progress(0, len(rows))
+
+	if options.DryRun {
+		if err := e.planImport(db, rows, options); err != nil {
+			return result, err
+		}
+		for _, row := range rows {
+			result.add(row, nil)
+			result.Rows = append(result.Rows, ImportRowResult{Row: row.Row, ID: row.ID, Action: row.Action, Errors: row.Errors})
+		}
+		result.rows = rows
+		options.progress(len(rows), len(rows))
+		return result, nil
+	}
+
+	batch := e.newBatch(db.Statement.Context, options)
+	if options.Mode == ImportModePartial {
+		if err := e.planImport(db, rows, options); err != nil {
+			return result, err
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := createBatch(tx, batch, len(rows)); err != nil {
+				return err
+			}
+			if err := e.bulkWrite(tx, batch, rows, options); err != nil {
+				return err
+			}
+			for _, row := range rows {
+				result.add(row, nil)
+			}
+			return finishBatch(tx, batch, result)
+		})
+		if err == nil {
+			result.BatchID = batch.ID
+			return result, nil
+		}
+		if helpers.CheckTimeout(err) {
+			result.Inserted, result.Updated, result.Unchanged = 0, 0, 0
+			return result, err
+		}
+		result = ImportResult{Mode: options.Mode, Patch: options.Patch, Total: len(rows), Errors: []ImportError{}}
+
+		/* Rows commit one by one, so the batch is stored first and its counts on every way out, past the deadline too */
+		if err := createBatch(db, batch, len(rows)); err != nil {
+			return result, err
+		}
+		result.BatchID = batch.ID
+		finish := func(err error) (ImportResult, error) {
+			if finishErr := finishBatch(db.WithContext(context.WithoutCancel(db.Statement.Context)), batch, result); err == nil {
+				err = finishErr
+			}
+			return result, err
+		}
+
+		for i := range rows {
+			if rows[i].Action == ImportActionReject {
+				result.add(rows[i], nil)
+				continue
+			}
+
+			err := db.Transaction(func(tx *gorm.DB) error {
+				repository := repositories.New(tx, e.definition())
+				previous, err := e.previousValues(repository, rows[i:i+1])
+				if err != nil {
+					return err
+				}
+				if err := e.importRow(repository, &rows[i]); err != nil {
+					return err
+				}
+				return e.saveImportRows(tx, batch, rows[i:i+1], previous)
+			})
+			if helpers.CheckTimeout(err) {
+				return finish(err)
+			}
+			result.add(rows[i], err)
+			options.progress(i+1, len(rows))
+		}
+
+		return finish(nil)
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := e.planImport(tx, rows, options); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if row.Action == ImportActionReject {
+				result.add(row, nil)
+			}
+		}
+		if result.Failed > 0 {
+			return fmt.Errorf("%w: %d of %d rows failed validation", ErrImportRejected, result.Failed, result.Total)
+		}
+
+		if err := createBatch(tx, batch, len(rows)); err != nil {
+			return err
+		}
+		if err := e.bulkWrite(tx, batch, rows, options); err != nil {
+			return fmt.Errorf("failed to write the rows: %w", err)
+		}
+		for _, row := range rows {
+			result.add(row, nil)
+		}
+		return finishBatch(tx, batch, result)
+	})
+	if err != nil {
+		result.Inserted, result.Updated, result.Unchanged = 0, 0, 0
+		return result, err
+	}
+
+	result.BatchID = batch.ID
+	return result, nil
+}
+
+/*
+Map Rows turns the records of a table into import rows. A column is found by its export header, its column name or one of its aliases,
+ignoring case, spaces and underscores, and columns without a match are
+ignored. The id column is optional, every required field must be present,
+a relation column may be replaced by the code or name column of the relation.
+A patch only needs the id column or the columns of the natural key, the
+other columns may be left out.
+*/
+func (e *Entity[T, S, R]) mapRows(table importTable, patch bool) ([]importRow, error) {
+	if len(table.Header) == 0 {
+		return nil, fmt.Errorf("%w: the file has no header row", ErrImportFile)
+	}
+
+	positions := map[string]int{}
+	for i, header := range table.Header {
+		if key := headerKey(header); key != "" {
+			if _, duplicate := positions[key]; !duplicate {
+				positions[key] = i
+			}
+		}
+	}
+
+	idColumn := columnPosition(positions, "ID", "id")
+
+	references := map[string]map[string]int{}
+	for _, relation := range e.Relations {
+		for _, column := range relation.labelColumns() {
+			if position := columnPosition(positions, relation.referenceHeaders(column)...); position >= 0 {
+				if references[relation.Column] == nil {
+					references[relation.Column] = map[string]int{}
+				}
+				references[relation.Column][column] = position
+			}
+		}
+	}
+
+	columns := make([]int, len(e.Fields))
+	missing := []string{}
+	keys := []string{}
+	for i, field := range e.Fields {
+		columns[i] = columnPosition(positions, field.names()...)
+		if columns[i] < 0 && len(references[field.Column]) == 0 && e.required(field.Column) {
+			missing = append(missing, field.Header)
+		}
+		if columns[i] < 0 && len(references[field.Column]) == 0 && slices.Contains(e.Unique, field.Column) {
+			keys = append(keys, field.Header)
+		}
+	}
+	if patch && idColumn < 0 && (len(e.Unique) == 0 || len(keys) > 0) {
+		return nil, fmt.Errorf("%w: a patch needs the ID column or the columns of the natural key", ErrImportFile)
+	}
+	if !patch && len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing required columns %s", ErrImportFile, strings.Join(missing, ", "))
+	}
+
+	rows := []importRow{}
+	for i, row := range table.Records {
+		if blankRow(row) {
+			continue
+		}
+
+		values := make([]string, len(e.Fields))
+		for j, column := range columns {
+			values[j] = cellAt(row, column)
+		}
+
+		refs := map[string]map[string]string{}
+		for relation, labels := range references {
+			refs[relation] = map[string]string{}
+			for column, position := range labels {
+				refs[relation][column] = cellAt(row, position)
+			}
+		}
+
+		var req R
+		e.setRequestValues(&req, values)
+		rows = append(rows, e.newImportRow(table.Lines[i], cellAt(row, idColumn), req, refs))
+	}
+
+	return rows, nil
+}
+
+/* New Import Row checks the id, the validate tags are checked once the parents are resolved */
+func (e *Entity[T, S, R]) newImportRow(line int, id string, req R, refs map[string]map[string]string) importRow {
+	row := importRow{Row: line, ID: strings.TrimSpace(id), Values: e.requestValues(&req), Refs: refs, Errors: map[string]string{}}
+
+	if row.ID != "" {
+		if _, err := uuid.Parse(row.ID); err != nil {
+			row.Errors["id"] = fmt.Sprintf("id %s is not a valid UUID", row.ID)
+		}
+	}
+
+	return row
+}
+
+/*
+Plan Import decides the action of every row without writing: parents given
+by code or name are resolved to their id, the blank cells of a patch take the
+stored values, rows without id take the id of the
+record with the same natural key, rows with unknown parents or a natural key
+that is repeated in the file or used by another record are rejected, existing
+rows are updated unless every field already has the imported value, and the
+rest are inserted.
+*/
+func (e *Entity[T, S, R]) planImport(db *gorm.DB, rows []importRow, options ImportOptions) error {
+	repository := repositories.New(db, e.definition())
+
+	for _, relation := range e.Relations {
+		if err := relation.resolveImport(db, rows, options.pending); err != nil {
+			return err
+		}
+	}
+
+	if options.Patch {
+		if err := e.fillBlanks(repository, rows); err != nil {
+			return err
+		}
+	}
+
+	for i := range rows {
+		e.validateRow(&rows[i])
+	}
+
+	for _, relation := range e.Relations {
+		if err := relation.checkImport(db, rows, options.pending); err != nil {
+			return err
+		}
+	}
+
+	if err := e.checkUnique(repository, rows); err != nil {
+		return err
+	}
+
+	/* A record is written by one row at most, a bulk upsert refuses to touch it twice */
+	first := map[string]int{}
+	for i, row := range rows {
+		if row.ID == "" || len(row.Errors) > 0 {
+			continue
+		}
+		id := strings.ToLower(row.ID)
+		if line, repeated := first[id]; repeated {
+			rows[i].Errors["id"] = fmt.Sprintf("id %s already used by row %d", row.ID, line)
+			continue
+		}
+		first[id] = row.Row
+	}
+
+	current, err := e.currentRows(repository, rows)
+	if err != nil {
+		return err
+	}
+
+	/* Rows whose id is not active may still be in the trash, where they are updated */
+	unknown := []string{}
+	for _, row := range rows {
+		if _, found := current[strings.ToLower(row.ID)]; row.ID != "" && len(row.Errors) == 0 && !found {
+			unknown = append(unknown, row.ID)
+		}
+	}
+	stored, err := storedIDs(repository, unknown)
+	if err != nil {
+		return err
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		if len(row.Errors) > 0 {
+			row.Action = ImportActionReject
+			continue
+		}
+
+		if row.ID == "" {
+			row.Action = ImportActionInsert
+			continue
+		}
+
+		values, found := current[strings.ToLower(row.ID)]
+		if !found && !stored[strings.ToLower(row.ID)] {
+			row.Action = ImportActionInsert
+			continue
+		}
+
+		row.Action = ImportActionUpdate
+		if found && sameValues(values, row.Values) {
+			row.Action = ImportActionUnchanged
+		}
+	}
+
+	return nil
+}
+
+/* Check Import rejects the rows whose parent is neither an untrashed record nor pending in an earlier sheet */
+func (r Relation) checkImport(db *gorm.DB, rows []importRow, pending map[string]*pendingRows) error {
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, row := range rows {
+		id := fmt.Sprint(row.Values[r.Column])
+		if _, invalid := row.Errors[r.Column]; id != "" && !invalid && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var parents []struct{ ID string }
+	if err := repositories.New(db, r.Entity.definition()).FindBy(&parents, "id", ids); err != nil {
+		return err
+	}
+
+	found := map[string]bool{}
+	if rows := pending[r.Entity.definition().Table]; rows != nil {
+		for id := range rows.IDs {
+			found[id] = true
+		}
+	}
+	for _, parent := range parents {
+		found[strings.ToLower(parent.ID)] = true
+	}
+
+	for i := range rows {
+		id := fmt.Sprint(rows[i].Values[r.Column])
+		if seen[id] && !found[strings.ToLower(id)] {
+			rows[i].Errors[r.Column] = fmt.Sprintf("%s %s not found", r.Column, id)
+		}
+	}
+
+	return nil
+}
+
+/* Current Rows loads the stored fields of the rows that carry an id, keyed by lower case id */
+func (e *Entity[T, S, R]) currentRows(repository repositories.Repository, rows []importRow) (map[string]repositories.Values, error) {
+	ids := []string{}
+	for _, row := range rows {
+		if _, invalid := row.Errors["id"]; row.ID != "" && !invalid {
+			ids = append(ids, row.ID)
+		}
+	}
+
+	current := map[string]repositories.Values{}
+	if len(ids) == 0 {
+		return current, nil
+	}
+
+	var stored []T
+	if err := repository.FindBy(&stored, "id", ids); err != nil {
+		return nil, err
+	}
+	for i := range stored {
+		current[strings.ToLower(e.rowID(&stored[i]))] = e.storedValues(&stored[i])
+	}
+
+	return current, nil
+}
+
+/*
+Check Unique rejects rows whose natural key repeats an earlier row or belongs
+to another record, a row without id takes the id of that record instead.
+*/
+func (e *Entity[T, S, R]) checkUnique(repository repositories.Repository, rows []importRow) error {
+	if len(e.Unique) == 0 {
+		return nil
+	}
+
+	first := map[string]int{}
+	values := []string{}
+	for i, row := range rows {
+		key, ok := e.uniqueKey(row.Values)
+		if !ok {
+			continue
+		}
+		if line, repeated := first[key]; repeated {
+			rows[i].Errors[e.Unique[len(e.Unique)-1]] = fmt.Sprintf("%s already used by row %d", e.uniqueLabel(row.Values), line)
+			continue
+		}
+		first[key] = row.Row
+		values = append(values, fmt.Sprint(row.Values[e.Unique[0]]))
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	owner, err := e.owners(repository, values)
+	if err != nil {
+		return err
+	}
+
+	for i, row := range rows {
+		key, ok := e.uniqueKey(row.Values)
+		if !ok || first[key] != row.Row {
+			continue
+		}
+		id, used := owner[key]
+		if used && row.ID == "" {
+			rows[i].ID = id
+			continue
+		}
+		if used && !strings.EqualFold(id, row.ID) {
+			rows[i].Errors[e.Unique[len(e.Unique)-1]] = fmt.Sprintf("%s already used by %s", e.uniqueLabel(row.Values), id)
+		}
+	}
+
+	return nil
+}
+
+/* Owners returns, by natural key, the id of the untrashed records whose first natural key column is one of values, ignoring case */
+func (e *Entity[T, S, R]) owners(repository repositories.Repository, values []string) (map[string]string, error) {
+	var stored []T
+	if err := repository.FindByFold(&stored, e.Unique[0], values); err != nil {
+		return nil, err
+	}
+
+	owner := map[string]string{}
+	for i := range stored {
+		if key, ok := e.uniqueKey(e.storedValues(&stored[i])); ok {
+			owner[key] = e.rowID(&stored[i])
+		}
+	}
+	return owner, nil
+}
+
+/*
+Fill Blanks gives the blank cells of a patch the stored value of the record
+the row updates, found by id, trashed or not, or else by natural key. Rows
+that match no record keep their blanks and are validated as new records.
+*/
+func (e *Entity[T, S, R]) fillBlanks(repository repositories.Repository, rows []importRow) error {
+	ids := []string{}
+	values := []string{}
+	for _, row := range rows {
+		if _, invalid := row.Errors["id"]; row.ID != "" && !invalid {
+			ids = append(ids, row.ID)
+		} else if _, ok := e.uniqueKey(row.Values); row.ID == "" && ok {
+			values = append(values, fmt.Sprint(row.Values[e.Unique[0]]))
+		}
+	}
+
+	owner := map[string]string{}
+	if len(values) > 0 {
+		found, err := e.owners(repository, values)
+		if err != nil {
+			return err
+		}
+		owner = found
+		for _, id := range owner {
+			ids = append(ids, id)
+		}
+	}
+
+	current := map[string]repositories.Values{}
+	for start := 0; start < len(ids); start += lookupChunk {
+		var stored []T
+		if err := repository.FindMany(&stored, ids[start:min(start+lookupChunk, len(ids))]); err != nil {
+			return err
+		}
+		for i := range stored {
+			current[strings.ToLower(e.rowID(&stored[i]))] = e.storedValues(&stored[i])
+		}
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		id := row.ID
+		if key, ok := e.uniqueKey(row.Values); id == "" && ok {
+			id = owner[key]
+		}
+
+		stored, found := current[strings.ToLower(id)]
+		if !found {
+			continue
+		}
+		for _, field := range e.Fields {
+			if strings.TrimSpace(fmt.Sprint(row.Values[field.Column])) == "" {
+				row.Values[field.Column] = fmt.Sprint(stored[field.Column])
+			}
+		}
+	}
+	return nil
+}
+
+/* Validate Row checks the values of a row against the validate tags of R, keeping earlier errors of a column */
+func (e *Entity[T, S, R]) validateRow(row *importRow) {
+	cells := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		cells[i] = fmt.Sprint(row.Values[field.Column])
+	}
+
+	var req R
+	e.setRequestValues(&req, cells)
+	for column, message := range requests.ValidateStruct(req) {
+		if _, found := row.Errors[column]; !found {
+			row.Errors[column] = message
+		}
+	}
+}
+
+/*
+Bulk Write gives the new rows an id and upserts every inserted and updated
+row with set-based statements, importChunk rows at a time to report progress.
+The written rows are recorded in batch, along with the values they replace.
+*/
+func (e *Entity[T, S, R]) bulkWrite(db *gorm.DB, batch *ImportBatch, rows []importRow, options ImportOptions) error {
+	repository := repositories.New(db, e.definition())
+	missing := 0
+	for _, row := range rows {
+		if row.Action == ImportActionInsert && row.ID == "" {
+			missing++
+		}
+	}
+	ids, err := newIDs(repository, missing)
+	if err != nil {
+		return err
+	}
+
+	records := []repositories.Record{}
+	for i := range rows {
+		row := &rows[i]
+		if row.Action != ImportActionInsert && row.Action != ImportActionUpdate {
+			continue
+		}
+		if row.ID == "" {
+			row.ID, ids = ids[0], ids[1:]
+		}
+		records = append(records, repositories.Record{ID: row.ID, Values: row.Values})
+	}
+
+	previous, err := e.previousValues(repository, rows)
+	if err != nil {
+		return err
+	}
+	if err := e.saveImportRows(db, batch, rows, previous); err != nil {
+		return err
+	}
+
+	processed := len(rows) - len(records)
+	options.progress(processed, len(rows))
+	for start := 0; start < len(records); start += importChunk {
+		end := min(start+importChunk, len(records))
+		if err := repository.Upsert(records[start:end]); err != nil {
+			return err
+		}
+		processed += end - start
+		options.progress(processed, len(rows))
+	}
+	return nil
+}
+
+func (o ImportOptions) progress(processed int, total int) {
+	if o.Progress != nil {
+		o.Progress(processed, total)
+	}
+}
+
+/* Import Row writes a planned row, a new row without id gets one, unchanged rows are left alone */
+func (e *Entity[T, S, R]) importRow(repository repositories.Repository, row *importRow) error {
+	switch row.Action {
+	case ImportActionReject:
+		return fmt.Errorf("%w: %s", ErrImportRejected, joinErrors(row.Errors))
+	case ImportActionUnchanged:
+		return nil
+	case ImportActionUpdate:
+		return repository.Update(row.ID, row.Values)
+	}
+
+	if row.ID == "" {
+		id, err := newID(repository)
+		if err != nil {
+			return err
+		}
+		row.ID = id
+	}
+	return repository.Insert(row.ID, row.Values)
+}
+
+/* Add counts a row by its action, a row with err is counted as failed */
+func (r *ImportResult) add(row importRow, err error) {
+	switch {
+	case err != nil || row.Action == ImportActionReject:
+		message := joinErrors(row.Errors)
+		if err != nil {
+			message = err.Error()
+		}
+		r.Failed++
+		r.Errors = append(r.Errors, ImportError{Row: row.Row, ID: row.ID, Message: message, Fields: row.Errors})
+	case row.Action == ImportActionInsert:
+		r.Inserted++
+	case row.Action == ImportActionUpdate:
+		r.Updated++
+	default:
+		r.Unchanged++
+	}
+}
+
+/* Measure records how long the import took and how many rows it went through per second */
+func (r *ImportResult) measure(duration time.Duration) {
+	r.DurationMs = duration.Milliseconds()
+	if seconds := duration.Seconds(); seconds > 0 {
+		r.RowsPerSecond = math.Round(float64(r.Total)/seconds*10) / 10
+	}
+}
+
+/* Unique Key joins the natural key of values, ok is false when a part of it is empty */
+func (e *Entity[T, S, R]) uniqueKey(values repositories.Values) (string, bool) {
+	parts := []string{}
+	for _, column := range e.Unique {
+		value := strings.ToLower(strings.TrimSpace(fmt.Sprint(values[column])))
+		if value == "" {
+			return "", false
+		}
+		parts = append(parts, value)
+	}
+	return strings.Join(parts, "\x00"), true
+}
+
+func (e *Entity[T, S, R]) uniqueLabel(values repositories.Values) string {
+	parts := []string{}
+	for _, column := range e.Unique {
+		parts = append(parts, fmt.Sprintf("%s %s", column, values[column]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (e *Entity[T, S, R]) rowID(row *T) string {
+	return fmt.Sprint(fieldByJSON(reflect.ValueOf(row).Elem(), "id").Interface())
+}
+
+/* Stored Values returns the writable fields of a stored row */
+func (e *Entity[T, S, R]) storedValues(row *T) repositories.Values {
+	value := reflect.ValueOf(row).Elem()
+
+	values := repositories.Values{}
+	for _, field := range e.Fields {
+		if target := fieldByJSON(value, field.Column); target.IsValid() {
+			values[field.Column] = target.Interface()
+		}
+	}
+	return values
+}
+
+func sameValues(current repositories.Values, values repositories.Values) bool {
+	for column, value := range values {
+		if fmt.Sprint(current[column]) != fmt.Sprint(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func joinErrors(fields map[string]string) string {
+	messages := []string{}
+	for _, message := range fields {
+		messages = append(messages, message)
+	}
+	sort.Strings(messages)
+	return strings.Join(messages, "; ")
+}
+
+/* Required reports whether the validate tag of column in R requires a value */
+func (e *Entity[T, S, R]) required(column string) bool {
+	for _, rule := range e.rules(column) {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+/* Names lists every header accepted for the field */
+func (f Field) names() []string {
+	names := append([]string{f.Header, f.Column}, f.Aliases...)
+	return append(names, HeaderAliases[f.Column]...)
+}
+
+/* Column Position returns the index of the first of names found in positions, or -1 */
+func columnPosition(positions map[string]int, names ...string) int {
+	for _, name := range names {
+		if position, ok := positions[headerKey(name)]; ok {
+			return position
+		}
+	}
+	return -1
+}
+
+/* Header Key normalizes a header so `Province ID`, `province_id` and `PROVINCE-ID` match */
+func headerKey(header string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(header), func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-' || r == '\t'
+	}), " ")
+}
+
+func cellAt(row []string, position int) string {
+	if position < 0 || position >= len(row) {
+		return ""
+	}
+	return row[position]
+}
+
+func blankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+/* New IDs generates count UUIDs that are not used yet, looked up in chunks through repository */
+func newIDs(repository repositories.Repository, count int) ([]string, error) {
+	ids := make([]string, 0, count)
+	for len(ids) < count {
+		candidates := make([]string, count-len(ids))
+		for i := range candidates {
+			candidates[i] = helpers.GenerateUUID()
+		}
+
+		used, err := storedIDs(repository, candidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range candidates {
+			if !used[strings.ToLower(id)] {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+/* Stored IDs returns which of ids are stored, trashed or not, keyed by lower case id */
+func storedIDs(repository repositories.Repository, ids []string) (map[string]bool, error) {
+	stored := map[string]bool{}
+	for start := 0; start < len(ids); start += lookupChunk {
+		end := min(start+lookupChunk, len(ids))
+
+		var rows []struct{ ID string }
+		if err := repository.FindMany(&rows, ids[start:end]); err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			stored[strings.ToLower(row.ID)] = true
+		}
+	}
+	return stored, nil
+}
+
+/* New ID generates a UUID that is not used yet, looked up through repository so it sees the open transaction */
+func newID(repository repositories.Repository) (string, error) {
+	for {
+		id := helpers.GenerateUUID()
+		exists, err := repository.Exists(id)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return id, nil
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package models
+
+import (
+	"data-referensi/app/repositories"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+/* Reference is a parent given by one of its label columns instead of its id */
+type reference struct {
+	Column string
+	Value  string
+}
+
+/* Pending Rows are the rows an earlier sheet of a dry run would write, by lower case id and by label */
+type pendingRows struct {
+	IDs    map[string]bool
+	Labels map[string]map[string][]string
+}
+
+/*
+New Pending Rows collects the planned rows of a sheet. A row without id gets
+a placeholder, so the rows of the next sheet can still reference it by code
+or name.
+*/
+func newPendingRows(sheet string, rows []importRow) *pendingRows {
+	pending := &pendingRows{IDs: map[string]bool{}, Labels: map[string]map[string][]string{}}
+	for _, row := range rows {
+		if row.Action == ImportActionReject {
+			continue
+		}
+
+		id := strings.ToLower(row.ID)
+		if id == "" {
+			id = fmt.Sprintf("pending:%s:%d", strings.ToLower(sheet), row.Row)
+		}
+		pending.IDs[id] = true
+
+		for _, column := range labelColumns {
+			value := labelKey(fmt.Sprint(row.Values[column]))
+			if _, found := row.Values[column]; !found || value == "" {
+				continue
+			}
+			if pending.Labels[column] == nil {
+				pending.Labels[column] = map[string][]string{}
+			}
+			pending.Labels[column][value] = append(pending.Labels[column][value], id)
+		}
+	}
+	return pending
+}
+
+/* Label Columns returns the columns of labelColumns the related entity has */
+func (r Relation) labelColumns() []string {
+	fields := map[string]bool{}
+	for _, field := range r.Entity.definition().Fields {
+		fields[field] = true
+	}
+
+	columns := []string{}
+	for _, column := range labelColumns {
+		if fields[column] {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+/* Reference Headers returns the headers of a label column of the relation, e.g. Province Code and province_code */
+func (r Relation) referenceHeaders(column string) []string {
+	label := ancestor{Path: []Relation{r}, Column: column}
+	return []string{label.Header(), label.Key()}
+}
+
+/*
+References lists the ways a row names its parent, in the order they are
+tried: a relation column that is not a UUID as a code and then as a name,
+followed by the code and name columns of the relation.
+*/
+func (r Relation) references(row importRow) []reference {
+	list := []reference{}
+	columns := r.labelColumns()
+
+	if value := strings.TrimSpace(fmt.Sprint(row.Values[r.Column])); value != "" {
+		if _, err := uuid.Parse(value); err == nil {
+			return list
+		}
+		for _, column := range columns {
+			list = append(list, reference{Column: column, Value: value})
+		}
+	}
+
+	for _, column := range columns {
+		if value := strings.TrimSpace(row.Refs[r.Column][column]); value != "" {
+			list = append(list, reference{Column: column, Value: value})
+		}
+	}
+	return list
+}
+
+/*
+Resolve Import replaces the parent references of rows with the parent's id,
+looked up among untrashed records and the pending rows of earlier sheets.
+The first reference that matches wins, a reference matching more than one
+parent or no reference matching at all rejects the row.
+*/
+func (r Relation) resolveImport(db *gorm.DB, rows []importRow, pending map[string]*pendingRows) error {
+	references := make([][]reference, len(rows))
+	wanted := map[string][]string{}
+	seen := map[string]bool{}
+	for i, row := range rows {
+		references[i] = r.references(row)
+		for _, item := range references[i] {
+			if key := item.Column + "\x00" + item.Value; !seen[key] {
+				seen[key] = true
+				wanted[item.Column] = append(wanted[item.Column], item.Value)
+			}
+		}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+
+	matches := map[string]map[string][]string{}
+	add := func(column string, value string, id string) {
+		if matches[column] == nil {
+			matches[column] = map[string][]string{}
+		}
+		for _, existing := range matches[column][value] {
+			if strings.EqualFold(existing, id) {
+				return
+			}
+		}
+		matches[column][value] = append(matches[column][value], id)
+	}
+
+	if rows := pending[r.Entity.definition().Table]; rows != nil {
+		for column, values := range rows.Labels {
+			for value, ids := range values {
+				for _, id := range ids {
+					add(column, value, id)
+				}
+			}
+		}
+	}
+
+	repository := repositories.New(db, r.Entity.definition())
+	for column, values := range wanted {
+		var parents []map[string]interface{}
+		if err := repository.FindByFold(&parents, column, values); err != nil {
+			return err
+		}
+		for _, parent := range parents {
+			add(column, labelKey(recordString(parent, column)), recordString(parent, "id"))
+		}
+	}
+
+	for i := range rows {
+		if len(references[i]) == 0 {
+			continue
+		}
+
+		resolved := false
+		for _, item := range references[i] {
+			ids := matches[item.Column][labelKey(item.Value)]
+			if len(ids) == 1 {
+				rows[i].Values[r.Column] = ids[0]
+				resolved = true
+				break
+			}
+			if len(ids) > 1 {
+				rows[i].Errors[r.Column] = fmt.Sprintf("%s %s %s is ambiguous, %d records match", r.Name, item.Column, item.Value, len(ids))
+				resolved = true
+				break
+			}
+		}
+		if !resolved {
+			first := references[i][0]
+			rows[i].Errors[r.Column] = fmt.Sprintf("%s %s %s not found", r.Name, first.Column, first.Value)
+		}
+	}
+
+	return nil
+}
+
+/* Label Key normalizes a code or name so matching ignores case and surrounding spaces */
+func labelKey(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"data-referensi/config"
+	"data-referensi/database"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+/* Test DB migrates a fresh SQLite database for the GORM repository */
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	config.DBDriver = config.DriverSQLite
+	config.DBRepository = config.RepositoryGorm
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestPlanImport(t *testing.T) {
+	const (
+		stored  = "8f0a3c52-6a4e-4b5c-9d0a-2f1e3b4c5d6e"
+		trashed = "1b2c3d4e-5f60-4718-8293-a4b5c6d7e8f9"
+		fresh   = "0d9e8f7a-6b5c-4d3e-8f2a-1b0c9d8e7f6a"
+	)
+
+	tests := []struct {
+		name    string
+		records [][]string
+		actions []string
+		errors  []string
+	}{
+		{
+			name:    "new row",
+			records: [][]string{{"", "10", "Other"}},
+			actions: []string{ImportActionInsert},
+		},
+		{
+			name:    "new row with its own id",
+			records: [][]string{{fresh, "10", "Other"}},
+			actions: []string{ImportActionInsert},
+		},
+		{
+			name:    "stored row unchanged",
+			records: [][]string{{stored, "1", "Islam"}},
+			actions: []string{ImportActionUnchanged},
+		},
+		{
+			name:    "stored row renamed",
+			records: [][]string{{stored, "1", "Muslim"}},
+			actions: []string{ImportActionUpdate},
+		},
+		{
+			name:    "trashed row",
+			records: [][]string{{trashed, "2", "Hindu"}},
+			actions: []string{ImportActionUpdate},
+		},
+		{
+			name:    "required name",
+			records: [][]string{{"", "10", ""}},
+			actions: []string{ImportActionReject},
+			errors:  []string{"name"},
+		},
+		{
+			name:    "stored row by its code",
+			records: [][]string{{"", "1", "Muslim"}},
+			actions: []string{ImportActionUpdate},
+		},
+		{
+			name:    "code of a stored row under another id",
+			records: [][]string{{fresh, "1", "Other"}},
+			actions: []string{ImportActionReject},
+			errors:  []string{"code"},
+		},
+		{
+			name:    "code repeated in the file",
+			records: [][]string{{"", "10", "Other"}, {"", "10", "Another"}},
+			actions: []string{ImportActionInsert, ImportActionReject},
+			errors:  []string{"", "code"},
+		},
+		{
+			name:    "id repeated in the file",
+			records: [][]string{{fresh, "10", "Other"}, {fresh, "11", "Another"}},
+			actions: []string{ImportActionInsert, ImportActionReject},
+			errors:  []string{"", "id"},
+		},
+		{
+			name:    "stored id repeated in the file in another case",
+			records: [][]string{{stored, "1", "Muslim"}, {strings.ToUpper(stored), "11", "Another"}},
+			actions: []string{ImportActionUpdate, ImportActionReject},
+			errors:  []string{"", "id"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := testDB(t)
+			err := db.Exec(`INSERT INTO mst_religions (id, code, name, created_at, updated_at, deleted_at) VALUES
+				(?, '1', 'Islam', 1, 1, NULL), (?, '2', 'Hindu', 1, 1, 1)`, stored, trashed).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			table := importTable{Header: []string{"ID", "Code", "Name"}, Records: test.records}
+			for i := range test.records {
+				table.Lines = append(table.Lines, i+2)
+			}
+			result, err := Religions.importTable(db, table, ImportOptions{DryRun: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, row := range result.Rows {
+				if row.Action != test.actions[i] {
+					t.Errorf("row %d action = %s, want %s, errors %v", row.Row, row.Action, test.actions[i], row.Errors)
+				}
+				want := ""
+				if i < len(test.errors) {
+					want = test.errors[i]
+				}
+				if _, found := row.Errors[want]; want != "" && !found {
+					t.Errorf("row %d errors = %v, want one on %s", row.Row, row.Errors, want)
+				}
+				if want == "" && len(row.Errors) > 0 {
+					t.Errorf("row %d errors = %v, want none", row.Row, row.Errors)
+				}
+			}
+			if len(result.Rows) != len(test.actions) {
+				t.Errorf("rows = %d, want %d", len(result.Rows), len(test.actions))
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package models
+
+import (
+	"context"
+	"data-referensi/app/repositories"
+	"data-referensi/helpers"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+/* Template Rows is how many rows below the header the validations of an import template cover */
+const templateRows = 1000
+
+const (
+	templateInstructionsSheet = "Instructions"
+	templateListsSheet        = "Lists"
+)
+
+/* Template Column is a column of an import template with the rules shown in its header comment */
+type templateColumn struct {
+	Header   string
+	Required bool
+	Rules    []string
+	Max      int
+	Relation *Relation
+	List     []string
+	Names    []string
+}
+
+/*
+Import Template writes an XLSX workbook to fill in for an import. The first
+sheet has the header row the importer expects, a comment on every header
+with the rules of the column, a length check on text columns and a dropdown
+of the parents on relation columns. The Instructions sheet explains the
+import and the Lists sheet holds the parents offered by the dropdowns.
+*/
+func (e *Entity[T, S, R]) ImportTemplate(ctx context.Context, w io.Writer) error {
+	columns, err := e.templateColumns(ctx)
+	if err != nil {
+		return err
+	}
+
+	file := excelize.NewFile()
+	defer file.Close()
+
+	if err := file.SetSheetName("Sheet1", e.Name); err != nil {
+		return err
+	}
+	if err := e.writeTemplateSheet(file, columns); err != nil {
+		return err
+	}
+
+	if _, err := file.NewSheet(templateInstructionsSheet); err != nil {
+		return err
+	}
+	if err := e.writeInstructions(file, columns); err != nil {
+		return err
+	}
+
+	if err := writeTemplateLists(file, columns); err != nil {
+		return err
+	}
+
+	file.SetActiveSheet(0)
+	return file.Write(w)
+}
+
+/* Template Columns describes every field, a relation column is filled with the parent's code or name */
+func (e *Entity[T, S, R]) templateColumns(ctx context.Context) ([]templateColumn, error) {
+	columns := []templateColumn{}
+	for _, field := range e.Fields {
+		column := templateColumn{Header: field.Header, Required: e.required(field.Column)}
+
+		for i := range e.Relations {
+			relation := e.Relations[i]
+			labels := relation.labelColumns()
+			if relation.Column != field.Column || len(labels) == 0 {
+				continue
+			}
+
+			var err error
+			column.Header = relation.referenceHeaders(labels[0])[0]
+			column.Relation = &relation
+			if column.List, column.Names, err = relation.templateList(ctx, labels[0]); err != nil {
+				return nil, err
+			}
+			alternatives := "name or id"
+			if labels[0] == "name" {
+				alternatives = "id"
+			}
+			column.Rules = append(column.Rules, fmt.Sprintf("%s of the %s, pick one from the list, the %s of the %s is accepted as well", title(labels[0]), title(relation.Name), alternatives, title(relation.Name)))
+		}
+
+		for _, rule := range e.rules(field.Column) {
+			name, param, _ := strings.Cut(rule, "=")
+			switch {
+			case name == "required":
+				column.Rules = append([]string{"Required"}, column.Rules...)
+			case name == "max" && column.Relation == nil:
+				column.Max, _ = strconv.Atoi(param)
+				column.Rules = append(column.Rules, fmt.Sprintf("At most %s characters", param))
+			case name == "numeric":
+				column.Rules = append(column.Rules, "Digits only")
+			}
+		}
+		if !column.Required {
+			column.Rules = append([]string{"Optional"}, column.Rules...)
+		}
+		if len(e.Unique) > 0 && e.Unique[len(e.Unique)-1] == field.Column {
+			scope := []string{}
+			for _, part := range e.Unique[:len(e.Unique)-1] {
+				for _, relation := range e.Relations {
+					if relation.Column == part {
+						scope = append(scope, title(relation.Name))
+					}
+				}
+			}
+			unique := "Unique"
+			if len(scope) > 0 {
+				unique += " per " + strings.Join(scope, " and ")
+			}
+			column.Rules = append(column.Rules, unique+", a row with the value of an existing record updates it")
+		}
+
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+/* Template List returns the label column of every untrashed parent, and its name when the label is a code */
+func (r Relation) templateList(ctx context.Context, column string) ([]string, []string, error) {
+	list, names := []string{}, []string{}
+	query := repositories.Query{SortBy: column, PageSize: exportChunk}
+
+	for query.Page = 1; ; query.Page++ {
+		var parents []map[string]interface{}
+		if err := r.Entity.repository(ctx).Get(&parents, query); err != nil {
+			return nil, nil, err
+		}
+
+		for _, parent := range parents {
+			list = append(list, recordString(parent, column))
+			if column != "name" {
+				names = append(names, recordString(parent, "name"))
+			}
+		}
+		if int64(len(parents)) < exportChunk {
+			return list, names, nil
+		}
+	}
+}
+
+func (e *Entity[T, S, R]) writeTemplateSheet(file *excelize.File, columns []templateColumn) error {
+	bold, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+
+	headers := []interface{}{}
+	for _, column := range columns {
+		headers = append(headers, column.Header)
+	}
+	if err := file.SetSheetRow(e.Name, "A1", &headers); err != nil {
+		return err
+	}
+
+	for i, width := range helpers.ExcelColumnWidths([][]interface{}{headers}) {
+		name, _ := excelize.ColumnNumberToName(i + 1)
+		if err := file.SetColWidth(e.Name, name, name, width); err != nil {
+			return err
+		}
+	}
+
+	listColumn := 1
+	for i, column := range columns {
+		name, _ := excelize.ColumnNumberToName(i + 1)
+		if err := file.SetCellStyle(e.Name, name+"1", name+"1", bold); err != nil {
+			return err
+		}
+
+		err := file.AddComment(e.Name, excelize.Comment{
+			Author:    e.Name,
+			Cell:      name + "1",
+			Paragraph: []excelize.RichTextRun{{Text: column.Header + ": " + strings.Join(column.Rules, ". ") + "."}},
+		})
+		if err != nil {
+			return err
+		}
+
+		cells := fmt.Sprintf("%s2:%s%d", name, name, templateRows+1)
+		switch {
+		case column.Relation != nil && len(column.List) > 0:
+			list, _ := excelize.ColumnNumberToName(listColumn)
+			validation := excelize.NewDataValidation(!column.Required)
+			validation.SetSqref(cells)
+			validation.SetSqrefDropList(fmt.Sprintf("%s!$%s$2:$%s$%d", templateListsSheet, list, list, len(column.List)+1))
+			validation.SetError(excelize.DataValidationErrorStyleWarning, column.Header, fmt.Sprintf("Not in the list of %s, the import rejects the row unless the %s is found by another label or its id", title(column.Relation.Name), title(column.Relation.Name)))
+			if err := file.AddDataValidation(e.Name, validation); err != nil {
+				return err
+			}
+		case column.Max > 0:
+			validation := excelize.NewDataValidation(!column.Required)
+			validation.SetSqref(cells)
+			if err := validation.SetRange(0, column.Max, excelize.DataValidationTypeTextLength, excelize.DataValidationOperatorBetween); err != nil {
+				return err
+			}
+			validation.SetError(excelize.DataValidationErrorStyleStop, column.Header, fmt.Sprintf("At most %d characters", column.Max))
+			if err := file.AddDataValidation(e.Name, validation); err != nil {
+				return err
+			}
+		}
+
+		if column.Relation != nil {
+			listColumn += 2
+		}
+	}
+
+	return file.SetPanes(e.Name, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+}
+
+func (e *Entity[T, S, R]) writeInstructions(file *excelize.File, columns []templateColumn) error {
+	lines := [][]interface{}{
+		{fmt.Sprintf("Import template for %s", e.Name)},
+		{},
+		{fmt.Sprintf("1. Fill in one record per row of the %s sheet, below the header row. Keep the headers, the columns may be reordered.", e.Name)},
+		{"2. Optional columns may be left empty or removed. The import reads the first sheet only."},
+		{"3. Pick parents from the dropdowns, the Lists sheet shows them with their names."},
+		{"4. A row whose unique column matches an existing record updates it, the other rows are inserted. Add an ID column to update records by id."},
+		{"5. Upload the file to the import endpoint, dry_run=true checks it without writing, mode=partial keeps the valid rows when others fail."},
+		{},
+		{"Column", "Required", "Rules"},
+	}
+	for _, column := range columns {
+		required := "No"
+		if column.Required {
+			required = "Yes"
+		}
+		lines = append(lines, []interface{}{column.Header, required, strings.Join(column.Rules, ". ")})
+	}
+
+	for i, line := range lines {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := file.SetSheetRow(templateInstructionsSheet, cell, &line); err != nil {
+			return err
+		}
+	}
+
+	if err := file.SetColWidth(templateInstructionsSheet, "A", "B", 24); err != nil {
+		return err
+	}
+	return file.SetColWidth(templateInstructionsSheet, "C", "C", 100)
+}
+
+/* Write Template Lists fills the Lists sheet, two columns per relation: the dropdown values and their names */
+func writeTemplateLists(file *excelize.File, columns []templateColumn) error {
+	created := false
+	position := 1
+	for _, column := range columns {
+		if column.Relation == nil {
+			continue
+		}
+		if !created {
+			if _, err := file.NewSheet(templateListsSheet); err != nil {
+				return err
+			}
+			created = true
+		}
+
+		values := [][]interface{}{{column.Header}}
+		if len(column.Names) > 0 {
+			values[0] = append(values[0], title(column.Relation.Name)+" Name")
+		}
+		for i, value := range column.List {
+			row := []interface{}{value}
+			if i < len(column.Names) {
+				row = append(row, column.Names[i])
+			}
+			values = append(values, row)
+		}
+
+		for i, row := range values {
+			cell, _ := excelize.CoordinatesToCellName(position, i+1)
+			if err := file.SetSheetRow(templateListsSheet, cell, &row); err != nil {
+				return err
+			}
+		}
+		position += 2
+	}
+	return nil
+}
+
+/* Rules returns the validate rules of column in R */
+func (e *Entity[T, S, R]) rules(column string) []string {
+	request := reflect.TypeOf(new(R)).Elem()
+	for i := 0; i < request.NumField(); i++ {
+		if jsonName(request.Field(i)) == column {
+			return strings.Split(request.Field(i).Tag.Get("validate"), ",")
+		}
+	}
+	return nil
+}
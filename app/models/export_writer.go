@@ -0,0 +1,322 @@
+package models
+
+import (
+	"archive/zip"
+	"bufio"
+	"data-referensi/helpers"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	ExportFormatXLSX   = "xlsx"
+	ExportFormatCSV    = "csv"
+	ExportFormatJSON   = "json"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatODS    = "ods"
+)
+
+/* Export Content Types maps every export format to its media type, XLSX first as the default */
+var ExportContentTypes = []struct {
+	Format      string
+	ContentType string
+}{
+	{ExportFormatXLSX, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+	{ExportFormatCSV, "text/csv"},
+	{ExportFormatJSON, "application/json"},
+	{ExportFormatNDJSON, "application/x-ndjson"},
+	{ExportFormatODS, "application/vnd.oasis.opendocument.spreadsheet"},
+}
+
+/* Export Content Type returns the media type of format, or an empty string for an unknown format */
+func ExportContentType(format string) string {
+	for _, item := range ExportContentTypes {
+		if item.Format == format {
+			return item.ContentType
+		}
+	}
+	return ""
+}
+
+/*
+Export Writer encodes an export. WriteHeader is called once, with the first
+chunk of rows so spreadsheet formats can size their columns, WriteRows once
+per chunk and Close finishes the document.
+*/
+type exportWriter interface {
+	WriteHeader(headers []string, sample [][]interface{}) error
+	WriteRows(rows [][]interface{}) error
+	Close() error
+}
+
+/* New Export Writer returns the writer of format, columns are the keys of JSON objects */
+func newExportWriter(format string, w io.Writer, columns []string) (exportWriter, error) {
+	switch format {
+	case ExportFormatXLSX:
+		return newXLSXWriter(w)
+	case ExportFormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case ExportFormatJSON:
+		return &jsonWriter{writer: bufio.NewWriter(w), columns: columns}, nil
+	case ExportFormatNDJSON:
+		return &jsonWriter{writer: bufio.NewWriter(w), columns: columns, lines: true}, nil
+	case ExportFormatODS:
+		return newODSWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+/* XLSX Writer fills a stream writer, the workbook is written out to out on Close unless it is shared */
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	writer, err := newXLSXSheetWriter(file, "Sheet1")
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	writer.out = w
+	return writer, nil
+}
+
+/* New XLSX Sheet Writer writes a sheet of a workbook shared with other writers, the caller saves it */
+func newXLSXSheetWriter(file *excelize.File, sheetName string) (*xlsxWriter, error) {
+	if index, _ := file.GetSheetIndex(sheetName); index < 0 {
+		if _, err := file.NewSheet(sheetName); err != nil {
+			return nil, err
+		}
+	}
+
+	stream, err := file.NewStreamWriter(sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create XLSX stream: %v", err)
+	}
+	return &xlsxWriter{file: file, stream: stream, row: 1}, nil
+}
+
+func (x *xlsxWriter) WriteHeader(headers []string, sample [][]interface{}) error {
+	for i, width := range helpers.ExcelColumnWidths(append([][]interface{}{toInterfaces(headers)}, sample...)) {
+		if err := x.stream.SetColWidth(i+1, i+1, width); err != nil {
+			return err
+		}
+	}
+	return x.WriteRows([][]interface{}{toInterfaces(headers)})
+}
+
+func (x *xlsxWriter) WriteRows(rows [][]interface{}) error {
+	for _, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, x.row)
+		if err := x.stream.SetRow(cell, row); err != nil {
+			return err
+		}
+		x.row++
+	}
+	return nil
+}
+
+func (x *xlsxWriter) Close() error {
+	if x.out == nil {
+		return x.stream.Flush()
+	}
+
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.out)
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvWriter) WriteHeader(headers []string, sample [][]interface{}) error {
+	return c.writer.Write(headers)
+}
+
+func (c *csvWriter) WriteRows(rows [][]interface{}) error {
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = exportCell(value)
+		}
+		if err := c.writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+/* JSON Writer writes an array of objects, or one object per line when lines is set */
+type jsonWriter struct {
+	writer  *bufio.Writer
+	columns []string
+	lines   bool
+	written int
+}
+
+func (j *jsonWriter) WriteHeader(headers []string, sample [][]interface{}) error {
+	if j.lines {
+		return nil
+	}
+	_, err := j.writer.WriteString("[")
+	return err
+}
+
+func (j *jsonWriter) WriteRows(rows [][]interface{}) error {
+	for _, row := range rows {
+		/* Built by hand so the keys keep the column order */
+		encoded := []byte("{")
+		for i, column := range j.columns {
+			if i >= len(row) {
+				break
+			}
+			key, _ := json.Marshal(column)
+			value, err := json.Marshal(row[i])
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				encoded = append(encoded, ',')
+			}
+			encoded = append(append(append(encoded, key...), ':'), value...)
+		}
+		encoded = append(encoded, '}')
+
+		separator := ""
+		if j.lines {
+			encoded = append(encoded, '\n')
+		} else if j.written > 0 {
+			separator = ","
+		}
+		if _, err := j.writer.WriteString(separator); err != nil {
+			return err
+		}
+		if _, err := j.writer.Write(encoded); err != nil {
+			return err
+		}
+		j.written++
+	}
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	if !j.lines {
+		if _, err := j.writer.WriteString("]"); err != nil {
+			return err
+		}
+	}
+	return j.writer.Flush()
+}
+
+/* ODS Writer streams an OpenDocument spreadsheet, content.xml is written row by row */
+type odsWriter struct {
+	archive *zip.Writer
+	content io.Writer
+}
+
+const odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+const odsManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
+ <manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="application/vnd.oasis.opendocument.spreadsheet"/>
+ <manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>
+</manifest:manifest>`
+
+const odsContentStart = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" office:version="1.2">
+<office:body><office:spreadsheet><table:table table:name="Sheet1">`
+
+const odsContentEnd = `</table:table></office:spreadsheet></office:body></office:document-content>`
+
+func newODSWriter(w io.Writer) (*odsWriter, error) {
+	archive := zip.NewWriter(w)
+
+	/* The mimetype entry must come first and be stored uncompressed */
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(mimetype, odsMimeType); err != nil {
+		return nil, err
+	}
+
+	manifest, err := archive.Create("META-INF/manifest.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(manifest, odsManifest); err != nil {
+		return nil, err
+	}
+
+	content, err := archive.Create("content.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(content, odsContentStart); err != nil {
+		return nil, err
+	}
+
+	return &odsWriter{archive: archive, content: content}, nil
+}
+
+func (o *odsWriter) WriteHeader(headers []string, sample [][]interface{}) error {
+	return o.WriteRows([][]interface{}{toInterfaces(headers)})
+}
+
+func (o *odsWriter) WriteRows(rows [][]interface{}) error {
+	var row strings.Builder
+	for _, values := range rows {
+		row.Reset()
+		row.WriteString("<table:table-row>")
+		for _, value := range values {
+			switch value.(type) {
+			case int, int32, int64, float32, float64:
+				fmt.Fprintf(&row, `<table:table-cell office:value-type="float" office:value="%v"><text:p>%v</text:p></table:table-cell>`, value, value)
+			default:
+				row.WriteString(`<table:table-cell office:value-type="string"><text:p>`)
+				xml.EscapeText(&row, []byte(exportCell(value)))
+				row.WriteString(`</text:p></table:table-cell>`)
+			}
+		}
+		row.WriteString("</table:table-row>")
+
+		if _, err := io.WriteString(o.content, row.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *odsWriter) Close() error {
+	if _, err := io.WriteString(o.content, odsContentEnd); err != nil {
+		return err
+	}
+	return o.archive.Close()
+}
+
+/* Export Cell formats a value for the text based formats */
+func exportCell(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"context"
+	"data-referensi/app/repositories"
+	"data-referensi/config"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+/* Transferable is implemented by every entity so it can be a sheet of a multi-sheet workbook */
+type Transferable interface {
+	sheetName() string
+	exportTo(ctx context.Context, writer exportWriter, options ExportOptions) error
+	importTable(db *gorm.DB, table importTable, options ImportOptions) (ImportResult, error)
+	definition() repositories.Definition
+}
+
+/* Hierarchy is a chain of entities exported and imported as one workbook, one sheet per level, parents first */
+type Hierarchy struct {
+	Name   string
+	Levels []Transferable
+}
+
+/* Hierarchy Sheet Result is the import result of one sheet */
+type HierarchySheetResult struct {
+	Sheet string `json:"sheet"`
+	ImportResult
+}
+
+/* Regions is the country, province, city, district and village hierarchy */
+var Regions = &Hierarchy{
+	Name:   "Regions",
+	Levels: []Transferable{Countries, Provinces, Cities, Districts, Villages},
+}
+
+/* Export writes every level into its own sheet of an XLSX workbook */
+func (h *Hierarchy) Export(ctx context.Context, options ExportOptions, w io.Writer) error {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	/* Progress counts the rows of every sheet together */
+	written, done := 0, 0
+	progress := options.Progress
+	for _, level := range h.Levels {
+		done = 0
+		if progress != nil {
+			options.Progress = func(processed int) {
+				done = processed
+				progress(written + processed)
+			}
+		}
+
+		writer, err := newXLSXSheetWriter(file, level.sheetName())
+		if err != nil {
+			return err
+		}
+
+		err = level.exportTo(ctx, writer, options)
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export %s: %w", strings.ToLower(level.sheetName()), err)
+		}
+		written += done
+	}
+
+	/* Drop the default sheet, unless a level is named after it */
+	if index, _ := file.GetSheetIndex("Sheet1"); index >= 0 && !h.hasSheet("Sheet1") {
+		file.DeleteSheet("Sheet1")
+	}
+
+	return file.Write(w)
+}
+
+/*
+Import reads the sheet of every level, matched by name, parents first, and
+skips levels without a sheet. Outside partial mode the whole workbook is one
+transaction. A dry run lets a level reference the rows of earlier sheets by
+id, code or name.
+*/
+func (h *Hierarchy) Import(ctx context.Context, filePath string, options ImportOptions) ([]HierarchySheetResult, error) {
+	file, err := excelize.OpenFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to open excel file: %v", ErrImportFile, err)
+	}
+	defer file.Close()
+
+	tables := map[string]importTable{}
+	for _, level := range h.Levels {
+		if index, _ := file.GetSheetIndex(level.sheetName()); index >= 0 {
+			if tables[level.sheetName()], err = sheetTable(file, level.sheetName()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("%w: the workbook has none of the sheets %s", ErrImportFile, strings.Join(h.sheetNames(), ", "))
+	}
+
+	options.pending = map[string]*pendingRows{}
+	results := []HierarchySheetResult{}
+
+	/* Progress counts the rows of every sheet together, blank rows included until their sheet is read */
+	totals := map[string]int{}
+	for sheet, table := range tables {
+		totals[sheet] = len(table.Records)
+	}
+	progress := options.Progress
+
+	importLevels := func(db *gorm.DB) error {
+		done := 0
+		for _, level := range h.Levels {
+			table, found := tables[level.sheetName()]
+			if !found {
+				continue
+			}
+
+			sheet := level.sheetName()
+			if progress != nil {
+				options.Progress = func(processed int, total int) {
+					totals[sheet] = total
+					sum := 0
+					for _, count := range totals {
+						sum += count
+					}
+					progress(done+processed, sum)
+				}
+			}
+
+			result, err := level.importTable(db, table, options)
+			results = append(results, HierarchySheetResult{Sheet: level.sheetName(), ImportResult: result})
+			if err != nil {
+				return fmt.Errorf("sheet %s: %w", level.sheetName(), err)
+			}
+
+			if options.DryRun {
+				options.pending[level.definition().Table] = newPendingRows(level.sheetName(), result.rows)
+			}
+			done += result.Total
+		}
+		return nil
+	}
+
+	db := config.DB.WithContext(ctx)
+	if options.DryRun || options.Mode == ImportModePartial {
+		return results, importLevels(db)
+	}
+
+	if err := db.Transaction(importLevels); err != nil {
+		for i := range results {
+			results[i].Inserted, results[i].Updated, results[i].Unchanged = 0, 0, 0
+			results[i].BatchID = ""
+		}
+		return results, err
+	}
+	return results, nil
+}
+
+func (h *Hierarchy) hasSheet(name string) bool {
+	for _, sheet := range h.sheetNames() {
+		if sheet == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Hierarchy) sheetNames() []string {
+	names := []string{}
+	for _, level := range h.Levels {
+		names = append(names, level.sheetName())
+	}
+	return names
+}
@@ -0,0 +1,282 @@
+package models
+
+import (
+	"context"
+	"data-referensi/app/repositories"
+	"data-referensi/config"
+	"data-referensi/helpers"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+/* Err Batch Reverted is returned when a batch is reverted a second time */
+var ErrBatchReverted = errors.New("the import batch has already been reverted")
+
+/* Import Batch is an import that wrote rows, Table is the table of the entity it imported */
+type ImportBatch struct {
+	ID         string  `json:"id"`
+	Entity     string  `json:"entity"`
+	Table      string  `json:"table_name" gorm:"column:table_name"`
+	FileName   string  `json:"file_name"`
+	Mode       string  `json:"mode"`
+	Total      int     `json:"total"`
+	Inserted   int     `json:"inserted"`
+	Updated    int     `json:"updated"`
+	Unchanged  int     `json:"unchanged"`
+	Failed     int     `json:"failed"`
+	CreatedAt  int64   `json:"created_at" gorm:"autoCreateTime:false"`
+	CreatedBy  *string `json:"created_by"`
+	RevertedAt *int64  `json:"reverted_at"`
+	RevertedBy *string `json:"reverted_by"`
+}
+
+/* Import Batch Row is a row written by a batch, Previous holds its values before an update as JSON */
+type ImportBatchRow struct {
+	ID       string  `json:"id"`
+	BatchID  string  `json:"batch_id"`
+	RecordID string  `json:"record_id"`
+	Action   string  `json:"action"`
+	Previous *string `json:"previous_values" gorm:"column:previous_values"`
+}
+
+func (ImportBatch) TableName() string {
+	return "import_batches"
+}
+
+func (ImportBatchRow) TableName() string {
+	return "import_batch_rows"
+}
+
+/* Import Batches lists the batches newest first, of the entity with table or name entity when it is set */
+func ImportBatches(ctx context.Context, entity string, page int, pageSize int) ([]ImportBatch, int64, error) {
+	query := config.DB.WithContext(ctx).Model(&ImportBatch{})
+	if entity != "" {
+		query = query.Where("table_name = ? OR LOWER(entity) = ?", entity, strings.ToLower(entity))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	batches := []ImportBatch{}
+	err := query.Order("created_at DESC").Order("id").Offset((page - 1) * pageSize).Limit(pageSize).Find(&batches).Error
+	return batches, total, err
+}
+
+/* Find Import Batch returns the batch with id, gorm.ErrRecordNotFound when there is none */
+func FindImportBatch(ctx context.Context, id string) (ImportBatch, error) {
+	var batch ImportBatch
+	err := config.DB.WithContext(ctx).Where("id = ?", id).Take(&batch).Error
+	return batch, err
+}
+
+/*
+Revert Import Batch undoes a batch in one transaction: the rows it updated
+get their previous values back and the rows it inserted are soft deleted.
+Changes made to those rows after the import are overwritten. Every row is
+audited with the revert as its source.
+*/
+func RevertImportBatch(ctx context.Context, id string) (ImportBatch, error) {
+	batch, err := FindImportBatch(ctx, id)
+	if err != nil {
+		return batch, err
+	}
+	if batch.RevertedAt != nil {
+		return batch, ErrBatchReverted
+	}
+
+	var entity Referable
+	for _, candidate := range Entities {
+		if candidate.definition().Table == batch.Table {
+			entity = candidate
+		}
+	}
+	if entity == nil {
+		return batch, fmt.Errorf("the import batch wrote the unknown table %s", batch.Table)
+	}
+
+	err = config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repository := repositories.New(tx, entity.definition())
+
+		var rows []ImportBatchRow
+		if err := tx.Where("batch_id = ?", batch.ID).Order("id").Find(&rows).Error; err != nil {
+			return err
+		}
+
+		inserted := []string{}
+		updated := []repositories.Record{}
+		for _, row := range rows {
+			if row.Action == ImportActionInsert {
+				inserted = append(inserted, row.RecordID)
+				continue
+			}
+
+			if row.Previous == nil {
+				continue
+			}
+			values := repositories.Values{}
+			if err := json.Unmarshal([]byte(*row.Previous), &values); err != nil {
+				return fmt.Errorf("row %s has invalid previous values: %v", row.RecordID, err)
+			}
+			updated = append(updated, repositories.Record{ID: row.RecordID, Values: values})
+		}
+
+		ids := []string{}
+		for _, record := range updated {
+			ids = append(ids, record.ID)
+		}
+		current, err := entity.storedRecords(repository, ids)
+		if err != nil {
+			return err
+		}
+		trail := newAuditTrail(ctx, batch.Entity, batch.Table, AuditSourceRevert, &batch.ID)
+		for _, id := range inserted {
+			trail.add(id, AuditActionDelete, nil, nil)
+		}
+		for _, record := range updated {
+			trail.add(record.ID, AuditActionUpdate, current[strings.ToLower(record.ID)], record.Values)
+		}
+
+		if err := repository.DeleteMany(inserted); err != nil {
+			return err
+		}
+		if err := trail.save(tx); err != nil {
+			return err
+		}
+		for start := 0; start < len(updated); start += importChunk {
+			if err := repository.Upsert(updated[start:min(start+importChunk, len(updated))]); err != nil {
+				return err
+			}
+		}
+
+		revertedAt := time.Now().UnixMilli()
+		batch.RevertedAt, batch.RevertedBy = &revertedAt, helpers.ActorID(ctx)
+		return tx.Model(&ImportBatch{}).Where("id = ?", batch.ID).
+			Updates(map[string]interface{}{"reverted_at": revertedAt, "reverted_by": batch.RevertedBy}).Error
+	})
+	if err != nil {
+		batch.RevertedAt, batch.RevertedBy = nil, nil
+	}
+	return batch, err
+}
+
+/* New Batch starts the batch of an import by the actor of ctx, its counts are filled in once the rows are written */
+func (e *Entity[T, S, R]) newBatch(ctx context.Context, options ImportOptions) *ImportBatch {
+	return &ImportBatch{
+		ID:        helpers.GenerateUUID(),
+		Entity:    e.Name,
+		Table:     e.Table,
+		FileName:  options.FileName,
+		Mode:      options.Mode,
+		CreatedAt: time.Now().UnixMilli(),
+		CreatedBy: helpers.ActorID(ctx),
+	}
+}
+
+/* Create Batch stores the batch of total rows before any of them is written, with zero counts */
+func createBatch(db *gorm.DB, batch *ImportBatch, total int) error {
+	batch.Total = total
+	return db.Create(batch).Error
+}
+
+/* Finish Batch stores the counts of result in the batch, once the rows are written or the import stopped */
+func finishBatch(db *gorm.DB, batch *ImportBatch, result ImportResult) error {
+	batch.Inserted, batch.Updated = result.Inserted, result.Updated
+	batch.Unchanged, batch.Failed = result.Unchanged, result.Failed
+	return db.Model(&ImportBatch{}).Where("id = ?", batch.ID).Updates(map[string]interface{}{
+		"inserted":  batch.Inserted,
+		"updated":   batch.Updated,
+		"unchanged": batch.Unchanged,
+		"failed":    batch.Failed,
+	}).Error
+}
+
+/* Previous Values returns, by lowercased id, the stored values of the updated rows */
+func (e *Entity[T, S, R]) previousValues(repository repositories.Repository, rows []importRow) (map[string]repositories.Values, error) {
+	updated := []string{}
+	for _, row := range rows {
+		if row.Action == ImportActionUpdate {
+			updated = append(updated, row.ID)
+		}
+	}
+	return e.storedRecords(repository, updated)
+}
+
+/* Stored Records returns, by lowercased id, the stored values of the rows with ids, trashed or not */
+func (e *Entity[T, S, R]) storedRecords(repository repositories.Repository, ids []string) (map[string]repositories.Values, error) {
+	records := map[string]repositories.Values{}
+	for start := 0; start < len(ids); start += lookupChunk {
+		var stored []T
+		if err := repository.FindMany(&stored, ids[start:min(start+lookupChunk, len(ids))]); err != nil {
+			return nil, err
+		}
+		for i := range stored {
+			records[strings.ToLower(e.rowID(&stored[i]))] = e.storedValues(&stored[i])
+		}
+	}
+	return records, nil
+}
+
+/* Batch Rows links the inserted and updated rows to batch, with their previous values as JSON */
+func batchRows(batch *ImportBatch, rows []importRow, previous map[string]repositories.Values) ([]ImportBatchRow, error) {
+	list := []ImportBatchRow{}
+	for _, row := range rows {
+		if row.Action != ImportActionInsert && row.Action != ImportActionUpdate {
+			continue
+		}
+
+		item := ImportBatchRow{ID: helpers.GenerateUUID(), BatchID: batch.ID, RecordID: row.ID, Action: row.Action}
+		if values, found := previous[strings.ToLower(row.ID)]; found {
+			encoded, err := json.Marshal(values)
+			if err != nil {
+				return nil, err
+			}
+			text := string(encoded)
+			item.Previous = &text
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+/* Audit Rows adds the inserted and updated rows of batch to trail, an update with the fields it changed */
+func auditRows(trail *auditTrail, rows []importRow, previous map[string]repositories.Values) {
+	for _, row := range rows {
+		switch row.Action {
+		case ImportActionInsert:
+			trail.add(row.ID, AuditActionCreate, nil, row.Values)
+		case ImportActionUpdate:
+			trail.add(row.ID, AuditActionUpdate, previous[strings.ToLower(row.ID)], row.Values)
+		}
+	}
+}
+
+/* Save Import Rows links rows to batch and audits them, in the transaction of db */
+func (e *Entity[T, S, R]) saveImportRows(db *gorm.DB, batch *ImportBatch, rows []importRow, previous map[string]repositories.Values) error {
+	list, err := batchRows(batch, rows, previous)
+	if err != nil {
+		return err
+	}
+	if err := saveBatchRows(db, list); err != nil {
+		return err
+	}
+
+	trail := newAuditTrail(db.Statement.Context, e.Name, e.Table, AuditSourceImport, &batch.ID)
+	auditRows(trail, rows, previous)
+	return trail.save(db)
+}
+
+/* Save Batch Rows stores rows in batches small enough for the parameter limit of SQL Server */
+func saveBatchRows(db *gorm.DB, rows []ImportBatchRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return db.CreateInBatches(rows, repositories.BatchSize(reflect.TypeOf(ImportBatchRow{}).NumField())).Error
+}
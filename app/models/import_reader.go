@@ -0,0 +1,308 @@
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+const (
+	ImportFormatXLSX   = "xlsx"
+	ImportFormatCSV    = "csv"
+	ImportFormatJSON   = "json"
+	ImportFormatNDJSON = "ndjson"
+)
+
+/* Import Table is the header and records of an import file, Lines holds the line or position of each record */
+type importTable struct {
+	Header  []string
+	Records [][]string
+	Lines   []int
+}
+
+/* CSV Delimiters are the separators tried when sniffing a CSV file */
+var csvDelimiters = []rune{',', ';', '\t', '|'}
+
+/* Read Import Table reads an XLSX, CSV, JSON or NDJSON file, detected from its extension and content */
+func readImportTable(filePath string) (importTable, error) {
+	format, err := detectImportFormat(filePath)
+	if err != nil {
+		return importTable{}, err
+	}
+
+	switch format {
+	case ImportFormatCSV:
+		return readCSV(filePath)
+	case ImportFormatJSON:
+		return readJSON(filePath)
+	case ImportFormatNDJSON:
+		return readNDJSON(filePath)
+	default:
+		return readXLSX(filePath)
+	}
+}
+
+/* Detect Import Format trusts a known extension and otherwise looks at the first bytes of the file */
+func detectImportFormat(filePath string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".xlsx", ".xlsm":
+		return ImportFormatXLSX, nil
+	case ".csv", ".tsv", ".txt":
+		return ImportFormatCSV, nil
+	case ".ndjson", ".jsonl":
+		return ImportFormatNDJSON, nil
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrImportFile, err)
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(file, head)
+	head = bytes.TrimLeft(bytes.TrimPrefix(head[:n], []byte("\xef\xbb\xbf")), " \t\r\n")
+
+	switch {
+	case bytes.HasPrefix(head, []byte("PK")):
+		return ImportFormatXLSX, nil
+	case bytes.HasPrefix(head, []byte("[")):
+		return ImportFormatJSON, nil
+	case bytes.HasPrefix(head, []byte("{")):
+		return ImportFormatNDJSON, nil
+	default:
+		return ImportFormatCSV, nil
+	}
+}
+
+func readXLSX(filePath string) (importTable, error) {
+	file, err := excelize.OpenFile(filePath)
+	if err != nil {
+		return importTable{}, fmt.Errorf("%w: failed to open excel file: %v", ErrImportFile, err)
+	}
+	defer file.Close()
+
+	return sheetTable(file, file.GetSheetName(0))
+}
+
+/* Sheet Table reads a sheet of a workbook, its first row is the header */
+func sheetTable(file *excelize.File, sheetName string) (importTable, error) {
+	rows, err := file.GetRows(sheetName)
+	if err != nil {
+		return importTable{}, fmt.Errorf("%w: failed to get rows of %s: %v", ErrImportFile, sheetName, err)
+	}
+
+	table := importTable{}
+	for i, row := range rows {
+		if i == 0 {
+			table.Header = row
+			continue
+		}
+		table.Records = append(table.Records, row)
+		table.Lines = append(table.Lines, i+1)
+	}
+
+	return table, nil
+}
+
+/*
+Read CSV decodes UTF-8 (with or without BOM), UTF-16 with BOM or, when the
+content is not valid UTF-8, Windows-1252, and sniffs the delimiter from the
+header line.
+*/
+func readCSV(filePath string) (importTable, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return importTable{}, fmt.Errorf("%w: %v", ErrImportFile, err)
+	}
+
+	content, err = decodeText(content)
+	if err != nil {
+		return importTable{}, fmt.Errorf("%w: %v", ErrImportFile, err)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = sniffDelimiter(content)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	table := importTable{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return importTable{}, fmt.Errorf("%w: %v", ErrImportFile, err)
+		}
+
+		if table.Header == nil {
+			table.Header = record
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		table.Records = append(table.Records, record)
+		table.Lines = append(table.Lines, line)
+	}
+
+	return table, nil
+}
+
+/* Read JSON reads an array of objects, the line of a record is its position in the array */
+func readJSON(filePath string) (importTable, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return importTable{}, fmt.Errorf("%w: %v", ErrImportFile, err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	decoder.UseNumber()
+
+	var objects []map[string]interface{}
+	if err := decoder.Decode(&objects); err != nil {
+		return importTable{}, fmt.Errorf("%w: expected a JSON array of objects: %v", ErrImportFile, err)
+	}
+
+	lines := make([]int, len(objects))
+	for i := range objects {
+		lines[i] = i + 1
+	}
+
+	return objectTable(objects, lines), nil
+}
+
+/* Read NDJSON reads one object per line, skipping blank lines */
+func readNDJSON(filePath string) (importTable, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return importTable{}, fmt.Errorf("%w: %v", ErrImportFile, err)
+	}
+	defer file.Close()
+
+	objects := []map[string]interface{}{}
+	lines := []int{}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(bytes.TrimPrefix(scanner.Bytes(), []byte("\xef\xbb\xbf")))
+		if len(text) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.UseNumber()
+
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil {
+			return importTable{}, fmt.Errorf("%w: line %d is not a JSON object: %v", ErrImportFile, line, err)
+		}
+		objects = append(objects, object)
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return importTable{}, fmt.Errorf("%w: %v", ErrImportFile, err)
+	}
+
+	return objectTable(objects, lines), nil
+}
+
+/* Object Table lays out JSON objects as a table whose header is the union of their keys */
+func objectTable(objects []map[string]interface{}, lines []int) importTable {
+	keys := map[string]bool{}
+	for _, object := range objects {
+		for key := range object {
+			keys[key] = true
+		}
+	}
+
+	table := importTable{Header: []string{}, Lines: lines}
+	for key := range keys {
+		table.Header = append(table.Header, key)
+	}
+	sort.Strings(table.Header)
+
+	for _, object := range objects {
+		record := make([]string, len(table.Header))
+		for i, key := range table.Header {
+			record[i] = jsonCell(object[key])
+		}
+		table.Records = append(table.Records, record)
+	}
+
+	return table
+}
+
+func jsonCell(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return fmt.Sprint(value)
+	default:
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	}
+}
+
+/* Decode Text converts UTF-16 (by BOM) and Windows-1252 content to UTF-8, dropping a UTF-8 BOM */
+func decodeText(content []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(content, []byte("\xef\xbb\xbf")):
+		return content[3:], nil
+	case bytes.HasPrefix(content, []byte("\xff\xfe")), bytes.HasPrefix(content, []byte("\xfe\xff")):
+		decoder := unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder()
+		decoded, _, err := transform.Bytes(decoder, content)
+		return decoded, err
+	case !utf8.Valid(content):
+		decoded, _, err := transform.Bytes(charmap.Windows1252.NewDecoder(), content)
+		return decoded, err
+	default:
+		return content, nil
+	}
+}
+
+/* Sniff Delimiter picks the candidate that occurs most often outside quotes in the header line */
+func sniffDelimiter(content []byte) rune {
+	counts := map[rune]int{}
+	quoted := false
+	for _, r := range string(content) {
+		if r == '"' {
+			quoted = !quoted
+			continue
+		}
+		if !quoted && (r == '\n' || r == '\r') {
+			break
+		}
+		if !quoted {
+			counts[r]++
+		}
+	}
+
+	delimiter := csvDelimiters[0]
+	for _, candidate := range csvDelimiters {
+		if counts[candidate] > counts[delimiter] {
+			delimiter = candidate
+		}
+	}
+	return delimiter
+}
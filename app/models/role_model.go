@@ -0,0 +1,377 @@
+package models
+
+import (
+	"context"
+	"data-referensi/config"
+	"data-referensi/helpers"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+/* Err Unknown Role is returned when roles are assigned by a name no role has */
+var ErrUnknownRole = errors.New("unknown role")
+
+/* Err Invalid Permission is returned for a permission that is not a valid pattern */
+var ErrInvalidPermission = errors.New("invalid permission")
+
+/* Err Permission Not Allowed is returned when a role would grant a permission its caller does not hold */
+var ErrPermissionNotAllowed = errors.New("permission not allowed")
+
+/* Role groups permissions, users get the permissions of their roles */
+type Role struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description *string  `json:"description"`
+	Permissions []string `json:"permissions" gorm:"-"`
+	CreatedAt   int64    `json:"created_at" gorm:"autoCreateTime:false"`
+	UpdatedAt   int64    `json:"updated_at" gorm:"autoUpdateTime:false"`
+}
+
+type RolePermission struct {
+	RoleID     string
+	Permission string
+}
+
+type UserRole struct {
+	UserID string
+	RoleID string
+}
+
+/* User Roles lists a user with the names of its roles */
+type UserRoles struct {
+	User
+	Roles []string `json:"roles" gorm:"-"`
+}
+
+func (Role) TableName() string {
+	return "roles"
+}
+
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
+
+func (UserRole) TableName() string {
+	return "user_roles"
+}
+
+/*
+Check Permission validates a permission pattern: `*`, or a group, an entity
+and an action such as `region.province:write`, where any part may be `*`.
+A `:` may separate the group from the entity as well, `education:*:restore`.
+*/
+func CheckPermission(permission string) error {
+	if permission == "*" {
+		return nil
+	}
+
+	if len(permissionParts(permission)) != 3 {
+		return fmt.Errorf("%w %q, expected <group>.<entity>:<action> or *", ErrInvalidPermission, permission)
+	}
+	return nil
+}
+
+/* Allowed reports whether one of patterns grants permission, a `<group>.<entity>:<action>` */
+func Allowed(patterns []string, permission string) bool {
+	wanted := permissionParts(permission)
+	for _, pattern := range patterns {
+		if pattern == "*" {
+			return true
+		}
+
+		parts := permissionParts(pattern)
+		if len(parts) != len(wanted) {
+			continue
+		}
+		granted := true
+		for i, part := range parts {
+			if part != "*" && !strings.EqualFold(part, wanted[i]) {
+				granted = false
+				break
+			}
+		}
+		if granted {
+			return true
+		}
+	}
+	return false
+}
+
+func permissionParts(permission string) []string {
+	return strings.FieldsFunc(strings.TrimSpace(permission), func(r rune) bool { return r == '.' || r == ':' })
+}
+
+/* User Permissions returns the permissions of every role of a user */
+func UserPermissions(ctx context.Context, userID string) ([]string, error) {
+	permissions := []string{}
+	err := config.DB.WithContext(ctx).Model(&RolePermission{}).
+		Distinct("role_permissions.permission").
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Order("role_permissions.permission").
+		Pluck("role_permissions.permission", &permissions).Error
+	return permissions, err
+}
+
+/* Roles lists every role by name, with its permissions */
+func Roles(ctx context.Context) ([]Role, error) {
+	db := config.DB.WithContext(ctx)
+
+	roles := []Role{}
+	if err := db.Order("name").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+
+	var permissions []RolePermission
+	if err := db.Order("permission").Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+	byRole := map[string][]string{}
+	for _, permission := range permissions {
+		byRole[permission.RoleID] = append(byRole[permission.RoleID], permission.Permission)
+	}
+	for i := range roles {
+		roles[i].Permissions = append([]string{}, byRole[roles[i].ID]...)
+	}
+	return roles, nil
+}
+
+/* Find Role returns the role with id and its permissions, gorm.ErrRecordNotFound when there is none */
+func FindRole(ctx context.Context, id string) (Role, error) {
+	db := config.DB.WithContext(ctx)
+
+	var role Role
+	if err := db.Where("id = ?", id).Take(&role).Error; err != nil {
+		return role, err
+	}
+
+	role.Permissions = []string{}
+	err := db.Model(&RolePermission{}).Where("role_id = ?", id).Order("permission").Pluck("permission", &role.Permissions).Error
+	return role, err
+}
+
+/*
+Save Role creates the role, or replaces the name, description and permissions
+of the role with id. Every permission it had and every permission it gets
+must be one granted, the permissions of the caller, so no one hands out or
+takes away more than they hold.
+*/
+func SaveRole(ctx context.Context, id string, name string, description *string, permissions []string, granted []string) (Role, error) {
+	for _, permission := range permissions {
+		if err := CheckPermission(permission); err != nil {
+			return Role{}, err
+		}
+	}
+	if err := checkGrants(permissions, granted); err != nil {
+		return Role{}, err
+	}
+
+	err := config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		if id == "" {
+			id = helpers.GenerateUUID()
+			role := Role{ID: id, Name: strings.TrimSpace(name), Description: description, CreatedAt: now, UpdatedAt: now}
+			if err := tx.Create(&role).Error; err != nil {
+				return err
+			}
+		} else {
+			current, err := rolePermissions(tx, "roles.id = ?", id)
+			if err != nil {
+				return err
+			}
+			if err := checkGrants(current, granted); err != nil {
+				return err
+			}
+
+			result := tx.Model(&Role{}).Where("id = ?", id).
+				Updates(map[string]interface{}{"name": strings.TrimSpace(name), "description": description, "updated_at": now})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+
+		if err := tx.Where("role_id = ?", id).Delete(&RolePermission{}).Error; err != nil {
+			return err
+		}
+		rows := []RolePermission{}
+		seen := map[string]bool{}
+		for _, permission := range permissions {
+			if permission = strings.TrimSpace(permission); !seen[permission] {
+				seen[permission] = true
+				rows = append(rows, RolePermission{RoleID: id, Permission: permission})
+			}
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return Role{}, err
+	}
+	return FindRole(ctx, id)
+}
+
+/* Delete Role removes a role, its users lose its permissions, which must all be granted, the permissions of the caller */
+func DeleteRole(ctx context.Context, id string, granted []string) error {
+	return config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := rolePermissions(tx, "roles.id = ?", id)
+		if err != nil {
+			return err
+		}
+		if err := checkGrants(current, granted); err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&Role{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Where("role_id = ?", id).Delete(&RolePermission{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("role_id = ?", id).Delete(&UserRole{}).Error
+	})
+}
+
+/* Users lists every user by username, with the names of its roles */
+func Users(ctx context.Context) ([]UserRoles, error) {
+	db := config.DB.WithContext(ctx)
+
+	var users []User
+	if err := db.Order("username").Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	var assigned []struct {
+		UserID string
+		Name   string
+	}
+	err := db.Model(&UserRole{}).Select("user_roles.user_id, roles.name").
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Order("roles.name").Scan(&assigned).Error
+	if err != nil {
+		return nil, err
+	}
+	byUser := map[string][]string{}
+	for _, row := range assigned {
+		byUser[row.UserID] = append(byUser[row.UserID], row.Name)
+	}
+
+	list := []UserRoles{}
+	for _, user := range users {
+		list = append(list, UserRoles{User: user, Roles: append([]string{}, byUser[user.ID]...)})
+	}
+	return list, nil
+}
+
+/*
+Set User Roles replaces the roles of the user with id by the roles named
+names. The roles it assigns and the ones it removes may only hold permissions
+of granted, those of the caller.
+*/
+func SetUserRoles(ctx context.Context, userID string, names []string, granted []string) error {
+	return config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&User{}).Where("id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		assigned := tx.Where("roles.id IN (?)", tx.Model(&UserRole{}).Select("role_id").Where("user_id = ?", userID))
+		if len(names) > 0 {
+			assigned = assigned.Or("roles.name IN ?", names)
+		}
+		permissions, err := rolePermissions(tx, assigned)
+		if err != nil {
+			return err
+		}
+		if err := checkGrants(permissions, granted); err != nil {
+			return err
+		}
+
+		return setUserRoles(tx, userID, names)
+	})
+}
+
+/* Set User Roles By Name is Set User Roles for the user with username, unchecked as it runs from the command line */
+func SetUserRolesByName(ctx context.Context, username string, names []string) error {
+	return config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user, err := findUser(tx, username)
+		if err != nil {
+			return err
+		}
+		return setUserRoles(tx, user.ID, names)
+	})
+}
+
+func setUserRoles(tx *gorm.DB, userID string, names []string) error {
+	roles := []Role{}
+	if len(names) > 0 {
+		if err := tx.Where("name IN ?", names).Find(&roles).Error; err != nil {
+			return err
+		}
+	}
+
+	found := map[string]bool{}
+	for _, role := range roles {
+		found[role.Name] = true
+	}
+	unknown := []string{}
+	for _, name := range names {
+		if !found[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w %s", ErrUnknownRole, strings.Join(unknown, ", "))
+	}
+
+	if err := tx.Where("user_id = ?", userID).Delete(&UserRole{}).Error; err != nil {
+		return err
+	}
+	rows := []UserRole{}
+	for _, role := range roles {
+		rows = append(rows, UserRole{UserID: userID, RoleID: role.ID})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Create(&rows).Error
+}
+
+/* Role Permissions returns the distinct permissions of the roles matched by query and args, conditions on roles */
+func rolePermissions(tx *gorm.DB, query interface{}, args ...interface{}) ([]string, error) {
+	permissions := []string{}
+	err := tx.Model(&RolePermission{}).
+		Distinct("role_permissions.permission").
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where(query, args...).
+		Pluck("role_permissions.permission", &permissions).Error
+	return permissions, err
+}
+
+/* Check Grants refuses the first of permissions that granted does not cover, a wildcard is only covered by a wildcard */
+func checkGrants(permissions []string, granted []string) error {
+	for _, permission := range permissions {
+		if !Allowed(granted, permission) {
+			return fmt.Errorf("%w: %s is beyond your own permissions", ErrPermissionNotAllowed, strings.TrimSpace(permission))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckPermission(t *testing.T) {
+	tests := []struct {
+		permission string
+		valid      bool
+	}{
+		{"*", true},
+		{"region.province:write", true},
+		{"region.*:read", true},
+		{"*.*:*", true},
+		{"education:*:restore", true},
+		{" admin.role:read ", true},
+		{"", false},
+		{"region", false},
+		{"region.province", false},
+		{"region.province:write:now", false},
+	}
+
+	for _, test := range tests {
+		err := CheckPermission(test.permission)
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("CheckPermission(%q) = %v, want valid %v", test.permission, err, test.valid)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidPermission) {
+			t.Errorf("CheckPermission(%q) = %v, want ErrInvalidPermission", test.permission, err)
+		}
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		name       string
+		patterns   []string
+		permission string
+		allowed    bool
+	}{
+		{"no patterns", nil, "region.province:read", false},
+		{"everything", []string{"*"}, "admin.role:write", true},
+		{"exact", []string{"region.province:read"}, "region.province:read", true},
+		{"other action", []string{"region.province:read"}, "region.province:write", false},
+		{"other entity", []string{"region.province:read"}, "region.city:read", false},
+		{"any entity", []string{"region.*:read"}, "region.city:read", true},
+		{"any entity other group", []string{"region.*:read"}, "biodata.religion:read", false},
+		{"any action", []string{"biodata.religion:*"}, "biodata.religion:restore", true},
+		{"any group", []string{"*.*:read"}, "education.major:read", true},
+		{"colon separated group", []string{"education:*:restore"}, "education.major:restore", true},
+		{"case insensitive", []string{"Region.Province:READ"}, "region.province:read", true},
+		{"second pattern", []string{"audit.log:read", "region.*:*"}, "region.city:import", true},
+		{"malformed pattern", []string{"region.province"}, "region.province:read", false},
+		{"wildcard needs a wildcard", []string{"region.province:read"}, "region.*:read", false},
+		{"wildcard covered by a wildcard", []string{"region.*:read"}, "region.*:read", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if allowed := Allowed(test.patterns, test.permission); allowed != test.allowed {
+				t.Errorf("Allowed(%q, %q) = %v, want %v", test.patterns, test.permission, allowed, test.allowed)
+			}
+		})
+	}
+}
+
+func TestCheckGrants(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		granted     []string
+		allowed     bool
+	}{
+		{"nothing asked", nil, []string{"region.*:read"}, true},
+		{"nothing granted", []string{"region.province:read"}, nil, false},
+		{"covered", []string{"region.province:read", "region.city:read"}, []string{"region.*:read"}, true},
+		{"one beyond", []string{"region.province:read", "region.city:write"}, []string{"region.*:read"}, false},
+		{"everything by a superuser", []string{"*"}, []string{"*"}, true},
+		{"everything by anyone else", []string{"*"}, []string{"*.*:*"}, false},
+		{"wildcard by a narrower grant", []string{"region.*:read"}, []string{"region.province:read"}, false},
+		{"wildcard by a wildcard", []string{"region.*:*"}, []string{"*.*:*"}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkGrants(test.permissions, test.granted)
+			if allowed := err == nil; allowed != test.allowed {
+				t.Errorf("checkGrants(%q, %q) = %v, want allowed %v", test.permissions, test.granted, err, test.allowed)
+			}
+			if err != nil && !errors.Is(err, ErrPermissionNotAllowed) {
+				t.Errorf("checkGrants(%q, %q) = %v, want ErrPermissionNotAllowed", test.permissions, test.granted, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"context"
+	"data-referensi/config"
+	"data-referensi/helpers"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+/* Err Invalid Credentials is returned by Login for an unknown or inactive user or a wrong password */
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+/* User is a person who signs in to change reference data */
+type User struct {
+	ID           string `json:"id"`
+	Username     string `json:"username"`
+	Name         string `json:"name"`
+	PasswordHash string `json:"-"`
+	Active       bool   `json:"active"`
+	CreatedAt    int64  `json:"created_at" gorm:"autoCreateTime:false"`
+	UpdatedAt    int64  `json:"updated_at" gorm:"autoUpdateTime:false"`
+}
+
+func (User) TableName() string {
+	return "users"
+}
+
+/* Min Password Length is the length a new password needs at least */
+const minPasswordLength = 8
+
+/* Create User stores an active user, its password hashed with bcrypt */
+func CreateUser(ctx context.Context, username string, name string, password string) (User, error) {
+	username = strings.ToLower(strings.TrimSpace(username))
+	if username == "" || strings.TrimSpace(name) == "" {
+		return User{}, errors.New("username and name are required")
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return User{}, err
+	}
+
+	now := time.Now().UnixMilli()
+	user := User{
+		ID:           helpers.GenerateUUID(),
+		Username:     username,
+		Name:         strings.TrimSpace(name),
+		PasswordHash: hash,
+		Active:       true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	return user, config.DB.WithContext(ctx).Create(&user).Error
+}
+
+/* Set User Password replaces the password of username and revokes its sessions */
+func SetUserPassword(ctx context.Context, username string, password string) error {
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user, err := findUser(tx, username)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&user).Updates(map[string]interface{}{"password_hash": hash, "updated_at": time.Now().UnixMilli()}).Error
+		if err != nil {
+			return err
+		}
+		return revokeSessions(tx, user.ID)
+	})
+}
+
+/* Set User Active enables or disables username, a disabled user loses its sessions */
+func SetUserActive(ctx context.Context, username string, active bool) error {
+	return config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user, err := findUser(tx, username)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&user).Updates(map[string]interface{}{"active": active, "updated_at": time.Now().UnixMilli()}).Error
+		if err != nil || active {
+			return err
+		}
+		return revokeSessions(tx, user.ID)
+	})
+}
+
+/* Find User returns the user with username, gorm.ErrRecordNotFound when there is none */
+func findUser(db *gorm.DB, username string) (User, error) {
+	var user User
+	err := db.Where("username = ?", strings.ToLower(strings.TrimSpace(username))).Take(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return user, fmt.Errorf("user %s not found: %w", username, err)
+	}
+	return user, err
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("the password needs at least %d characters", minPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+/* Unknown User Hash is compared against when the username is unknown, so both cases take as long */
+var unknownUserHash, _ = bcrypt.GenerateFromPassword([]byte("unknown user"), bcrypt.DefaultCost)
+
+/* Check Password returns the active user with username and password, ErrInvalidCredentials otherwise */
+func checkPassword(db *gorm.DB, username string, password string) (User, error) {
+	user, err := findUser(db, username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		bcrypt.CompareHashAndPassword(unknownUserHash, []byte(password))
+		return user, ErrInvalidCredentials
+	}
+	if err != nil {
+		return user, err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil || !user.Active {
+		return user, ErrInvalidCredentials
+	}
+	return user, nil
+}
@@ -0,0 +1,215 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"data-referensi/config"
+	"data-referensi/helpers"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+/* Err Invalid Token is returned for a malformed, expired or revoked access or refresh token */
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+/*
+User Session is a login. Its refresh token is stored hashed and replaced on
+every refresh, the access tokens carry the session id so revoking the session
+revokes them too.
+*/
+type UserSession struct {
+	ID          string `json:"id"`
+	UserID      string `json:"user_id"`
+	RefreshHash string `json:"-"`
+	ExpiresAt   int64  `json:"expires_at"`
+	CreatedAt   int64  `json:"created_at" gorm:"autoCreateTime:false"`
+	RevokedAt   *int64 `json:"revoked_at"`
+}
+
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
+
+/* Tokens are issued at login and on refresh, ExpiresIn is the lifetime of the access token in seconds */
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+/* Access Claims are the claims of an access token, the subject is the user id and the id is the session id */
+type accessClaims struct {
+	Username string `json:"username"`
+	jwt.RegisteredClaims
+}
+
+/* Login checks the password of username and opens a session */
+func Login(ctx context.Context, username string, password string) (Tokens, error) {
+	var tokens Tokens
+	err := config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user, err := checkPassword(tx, username, password)
+		if err != nil {
+			return err
+		}
+
+		session := UserSession{ID: helpers.GenerateUUID(), UserID: user.ID, CreatedAt: time.Now().UnixMilli()}
+		refresh, err := session.rotate()
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+
+		tokens, err = issueTokens(user, session, refresh)
+		return err
+	})
+	return tokens, err
+}
+
+/*
+Refresh trades a refresh token for new tokens, the old refresh token stops
+working. Presenting a refresh token that was already traded revokes the
+session, since only a stolen copy can still hold it.
+*/
+func Refresh(ctx context.Context, refreshToken string) (Tokens, error) {
+	sessionID, _, found := strings.Cut(refreshToken, ".")
+	if !found {
+		return Tokens{}, ErrInvalidToken
+	}
+
+	var tokens Tokens
+	var reused bool
+	err := config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		session, user, err := activeSession(tx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		if subtle.ConstantTimeCompare([]byte(session.RefreshHash), []byte(tokenHash(refreshToken))) != 1 {
+			reused = true
+			return ErrInvalidToken
+		}
+
+		refresh, err := session.rotate()
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&session).Updates(map[string]interface{}{"refresh_hash": session.RefreshHash, "expires_at": session.ExpiresAt}).Error
+		if err != nil {
+			return err
+		}
+
+		tokens, err = issueTokens(user, session, refresh)
+		return err
+	})
+	if reused {
+		if err := Logout(ctx, sessionID); err != nil {
+			return tokens, err
+		}
+	}
+	return tokens, err
+}
+
+/* Logout revokes a session, its access and refresh tokens stop working */
+func Logout(ctx context.Context, sessionID string) error {
+	return config.DB.WithContext(ctx).Model(&UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", time.Now().UnixMilli()).Error
+}
+
+/* Authenticate returns the user of an access token and its session id, ErrInvalidToken when it is not accepted */
+func Authenticate(ctx context.Context, accessToken string) (User, string, error) {
+	var claims accessClaims
+	_, err := jwt.ParseWithClaims(accessToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		return config.JWTSecret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return User{}, "", ErrInvalidToken
+	}
+
+	_, user, err := activeSession(config.DB.WithContext(ctx), claims.ID)
+	if err != nil || user.ID != claims.Subject {
+		return User{}, "", ErrInvalidToken
+	}
+	return user, claims.ID, nil
+}
+
+/* Active Session returns a session that is neither revoked nor expired, with its user while active */
+func activeSession(db *gorm.DB, id string) (UserSession, User, error) {
+	var session UserSession
+	var user User
+
+	err := db.Where("id = ? AND revoked_at IS NULL AND expires_at > ?", id, time.Now().UnixMilli()).Take(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return session, user, ErrInvalidToken
+	}
+	if err != nil {
+		return session, user, err
+	}
+
+	err = db.Where("id = ? AND active = ?", session.UserID, true).Take(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return session, user, ErrInvalidToken
+	}
+	return session, user, err
+}
+
+/* Revoke Sessions revokes every open session of a user */
+func revokeSessions(db *gorm.DB, userID string) error {
+	return db.Model(&UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now().UnixMilli()).Error
+}
+
+/* Rotate gives the session a new refresh token, prefixed by the session id, and returns it */
+func (s *UserSession) rotate() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	token := s.ID + "." + base64.RawURLEncoding.EncodeToString(secret)
+	s.RefreshHash = tokenHash(token)
+	s.ExpiresAt = time.Now().Add(config.RefreshTokenTTL).UnixMilli()
+	return token, nil
+}
+
+func issueTokens(user User, session UserSession, refresh string) (Tokens, error) {
+	now := time.Now()
+	claims := accessClaims{
+		Username: user.Username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        session.ID,
+			Subject:   user.ID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(config.AccessTokenTTL)),
+		},
+	}
+
+	access, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(config.JWTSecret)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	return Tokens{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(config.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
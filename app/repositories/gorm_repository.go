@@ -0,0 +1,200 @@
+package repositories
+
+import (
+	"data-referensi/helpers"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+/* Gorm Repository stores an entity in plain tables, used for SQLite and Postgres */
+type GormRepository struct {
+	db         *gorm.DB
+	definition Definition
+}
+
+func (r *GormRepository) Get(dest interface{}, q Query) error {
+	tx := r.table()
+	if q.Trashed {
+		tx = tx.Where("deleted_at IS NOT NULL")
+	} else {
+		tx = tx.Where("deleted_at IS NULL")
+	}
+
+	if q.Filter != "" && len(r.definition.Search) > 0 {
+		conditions := []string{}
+		args := []interface{}{}
+		for _, column := range r.definition.Search {
+			conditions = append(conditions, fmt.Sprintf("LOWER(%s) LIKE ?", column))
+			args = append(args, "%"+strings.ToLower(q.Filter)+"%")
+		}
+		tx = tx.Where(strings.Join(conditions, " OR "), args...)
+	}
+
+	if q.ParentID != "" && r.definition.Parent != nil {
+		tx = tx.Where(fmt.Sprintf("%s = ?", r.definition.Parent.Column), q.ParentID)
+	}
+
+	/* id breaks ties so pages do not overlap, SQL Server refuses it twice in ORDER BY */
+	sortBy := r.sortColumn(q.SortBy)
+	tx = tx.Order(clause.OrderByColumn{
+		Column: clause.Column{Name: sortBy},
+		Desc:   strings.EqualFold(q.SortDirection, "desc"),
+	})
+	if sortBy != "id" {
+		tx = tx.Order("id")
+	}
+
+	if q.PageSize > 0 {
+		page := q.Page
+		if page < 1 {
+			page = 1
+		}
+		tx = tx.Offset((page - 1) * int(q.PageSize)).Limit(int(q.PageSize))
+	}
+
+	return tx.Scan(dest).Error
+}
+
+func (r *GormRepository) GetByParent(dest interface{}, parentId string) error {
+	return r.table().
+		Where("deleted_at IS NULL").
+		Where(fmt.Sprintf("%s = ?", r.definition.Parent.Column), parentId).
+		Order(r.sortColumn("name")).
+		Scan(dest).Error
+}
+
+func (r *GormRepository) Find(dest interface{}, id string) error {
+	return r.table().Where("id = ?", id).Scan(dest).Error
+}
+
+func (r *GormRepository) FindMany(dest interface{}, ids []string) error {
+	return r.table().Where("id IN ?", ids).Scan(dest).Error
+}
+
+func (r *GormRepository) Insert(id string, values Values) error {
+	now := time.Now()
+	created_at := now.UnixMilli()
+	updated_at := now.UnixMilli()
+
+	actor := r.actor()
+	row := r.fieldValues(values)
+	row["id"] = id
+	row["created_at"] = created_at
+	row["created_by"] = actor
+	row["updated_at"] = updated_at
+	row["updated_by"] = actor
+
+	return r.table().Create(row).Error
+}
+
+func (r *GormRepository) Update(id string, values Values) error {
+	now := time.Now()
+	actor := r.actor()
+
+	row := r.fieldValues(values)
+	row["updated_at"] = now.UnixMilli()
+	row["updated_by"] = actor
+
+	return r.table().Where("id = ?", id).Updates(row).Error
+}
+
+func (r *GormRepository) Delete(id string) error {
+	now := time.Now()
+
+	return r.table().Where("id = ?", id).Updates(map[string]interface{}{
+		"deleted_at": now.UnixMilli(),
+		"deleted_by": r.actor(),
+	}).Error
+}
+
+func (r *GormRepository) Restore(id string) error {
+	return r.table().Where("id = ?", id).Updates(map[string]interface{}{
+		"deleted_at": nil,
+		"deleted_by": nil,
+		"updated_at": time.Now().UnixMilli(),
+		"updated_by": r.actor(),
+	}).Error
+}
+
+func (r *GormRepository) Count(trashed bool) int64 {
+	return count(r.db, r.definition.Table, trashed)
+}
+
+func (r *GormRepository) Exists(id string) (bool, error) {
+	return exists(r.db, r.definition.Table, id)
+}
+
+func (r *GormRepository) DeleteMany(ids []string) error {
+	return deleteMany(r.db, r.definition.Table, ids)
+}
+
+func (r *GormRepository) FindBy(dest interface{}, column string, values []string) error {
+	return findBy(r.db, r.definition.Table, dest, column, values)
+}
+
+func (r *GormRepository) FindByFold(dest interface{}, column string, values []string) error {
+	return findByFold(r.db, r.definition.Table, dest, column, values)
+}
+
+/* Upsert writes the records in batches of multi-row inserts that update the rows whose id already exists */
+func (r *GormRepository) Upsert(records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+	actor := r.actor()
+	rows := make([]map[string]interface{}, len(records))
+	for i, record := range records {
+		row := r.fieldValues(record.Values)
+		row["id"] = record.ID
+		row["created_at"] = now
+		row["created_by"] = actor
+		row["updated_at"] = now
+		row["updated_by"] = actor
+		rows[i] = row
+	}
+
+	updates := append(append([]string{}, r.definition.Fields...), "updated_at", "updated_by")
+	return r.table().
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, DoUpdates: clause.AssignmentColumns(updates)}).
+		CreateInBatches(rows, BatchSize(len(rows[0]))).Error
+}
+
+/* Actor returns the id of the user or API key acting through the context of the repository, nil for none */
+func (r *GormRepository) actor() *string {
+	return helpers.ActorID(r.db.Statement.Context)
+}
+
+func (r *GormRepository) table() *gorm.DB {
+	return r.db.Table(r.definition.Table)
+}
+
+/* Sort Column only accepts known columns so sort_by cannot inject SQL */
+func (r *GormRepository) sortColumn(sortBy string) string {
+	columns := append([]string{"id", "created_at", "updated_at"}, r.definition.Fields...)
+	for _, column := range columns {
+		if column == sortBy {
+			return column
+		}
+	}
+
+	for _, column := range r.definition.Fields {
+		if column == "name" {
+			return column
+		}
+	}
+	return r.definition.Fields[0]
+}
+
+func (r *GormRepository) fieldValues(values Values) map[string]interface{} {
+	row := map[string]interface{}{}
+	for _, field := range r.definition.Fields {
+		row[field] = values[field]
+	}
+	return row
+}
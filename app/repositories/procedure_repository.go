@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"data-referensi/helpers"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+/* Procedure Repository stores an entity through the sp_mst_* procedures of SQL Server */
+type ProcedureRepository struct {
+	db         *gorm.DB
+	definition Definition
+}
+
+func (r *ProcedureRepository) Get(dest interface{}, q Query) error {
+	sp := r.procedure("get")
+	if q.Trashed {
+		sp = r.procedure("has_deleted")
+	}
+
+	query := fmt.Sprintf(`
+        EXEC %s
+        @Filter = ?,
+        @SortBy = ?,
+        @SortDirection = ?,
+        @Page = ?,
+        @PageSize = ?
+    `, sp)
+	args := []interface{}{q.Filter, q.SortBy, q.SortDirection, q.Page, q.PageSize}
+
+	if q.ParentID != "" && r.definition.Parent != nil {
+		query += ", @ParentId = ?"
+		args = append(args, q.ParentID)
+	}
+
+	return r.db.Raw(query, args...).Scan(dest).Error
+}
+
+func (r *ProcedureRepository) GetByParent(dest interface{}, parentId string) error {
+	parent := r.definition.Parent
+
+	query := fmt.Sprintf(`
+		EXEC %s
+		@%s = ?
+	`, parent.Procedure, parent.Param)
+
+	return r.db.Raw(query, parentId).Scan(dest).Error
+}
+
+func (r *ProcedureRepository) Find(dest interface{}, id string) error {
+	query := fmt.Sprintf(`
+		EXEC %s
+		@id = ?
+	`, r.procedure("get_by_id"))
+
+	return r.db.Raw(query, id).Scan(dest).Error
+}
+
+func (r *ProcedureRepository) FindMany(dest interface{}, ids []string) error {
+	query := fmt.Sprintf(`
+		EXEC %s
+		@ids = ?
+	`, r.procedure("get_by_ids"))
+
+	return r.db.Raw(query, strings.Join(ids, ",")).Scan(dest).Error
+}
+
+func (r *ProcedureRepository) Insert(id string, values Values) error {
+	now := time.Now()
+	created_at := now.UnixMilli()
+	updated_at := now.UnixMilli()
+	created_by := r.actor()
+	updated_by := r.actor()
+
+	params, args := r.fieldParams(id, values)
+	params = append(params, "@created_at = ?", "@created_by = ?", "@updated_at = ?", "@updated_by = ?")
+	args = append(args, created_at, created_by, updated_at, updated_by)
+
+	query := fmt.Sprintf("EXEC %s %s", r.procedure("insert"), strings.Join(params, ", "))
+
+	return r.db.Exec(query, args...).Error
+}
+
+func (r *ProcedureRepository) Update(id string, values Values) error {
+	now := time.Now()
+	updated_at := now.UnixMilli()
+	updated_by := r.actor()
+
+	params, args := r.fieldParams(id, values)
+	params = append(params, "@updated_at = ?", "@updated_by = ?")
+	args = append(args, updated_at, updated_by)
+
+	query := fmt.Sprintf("EXEC %s %s", r.procedure("update"), strings.Join(params, ", "))
+
+	return r.db.Exec(query, args...).Error
+}
+
+func (r *ProcedureRepository) Delete(id string) error {
+	now := time.Now()
+	deleted_at := now.UnixMilli()
+	deleted_by := r.actor()
+
+	query := fmt.Sprintf(`
+		EXEC %s
+		@id = ?,
+		@deleted_at = ?,
+		@deleted_by = ?
+	`, r.procedure("delete"))
+
+	return r.db.Exec(query, id, deleted_at, deleted_by).Error
+}
+
+func (r *ProcedureRepository) Restore(id string) error {
+	now := time.Now()
+	updated_at := now.UnixMilli()
+	updated_by := r.actor()
+
+	query := fmt.Sprintf(`
+		EXEC %s
+		@id = ?,
+		@updated_at = ?,
+		@updated_by = ?
+	`, r.procedure("restore"))
+
+	return r.db.Exec(query, id, updated_at, updated_by).Error
+}
+
+func (r *ProcedureRepository) Count(trashed bool) int64 {
+	return count(r.db, r.definition.Table, trashed)
+}
+
+func (r *ProcedureRepository) Exists(id string) (bool, error) {
+	return exists(r.db, r.definition.Table, id)
+}
+
+func (r *ProcedureRepository) DeleteMany(ids []string) error {
+	return deleteMany(r.db, r.definition.Table, ids)
+}
+
+func (r *ProcedureRepository) FindBy(dest interface{}, column string, values []string) error {
+	return findBy(r.db, r.definition.Table, dest, column, values)
+}
+
+func (r *ProcedureRepository) FindByFold(dest interface{}, column string, values []string) error {
+	return findByFold(r.db, r.definition.Table, dest, column, values)
+}
+
+/*
+Upsert stages the records into a temporary table with multi-row inserts and
+merges it into the table in one statement, bypassing the row by row
+procedures. The temporary table belongs to the connection, so the whole
+write runs in a transaction.
+*/
+func (r *ProcedureRepository) Upsert(records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	table := r.definition.Table
+	stage := "#import_" + table
+	columns := append([]string{"id"}, r.definition.Fields...)
+	placeholder := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(fmt.Sprintf(`
+			IF OBJECT_ID('tempdb..%s') IS NOT NULL DROP TABLE %s;
+			SELECT TOP 0 %s INTO %s FROM %s
+		`, stage, stage, strings.Join(columns, ", "), stage, table)).Error
+		if err != nil {
+			return err
+		}
+
+		batch := BatchSize(len(columns))
+		for start := 0; start < len(records); start += batch {
+			end := min(start+batch, len(records))
+
+			placeholders := make([]string, 0, end-start)
+			args := make([]interface{}, 0, (end-start)*len(columns))
+			for _, record := range records[start:end] {
+				placeholders = append(placeholders, placeholder)
+				args = append(args, record.ID)
+				for _, field := range r.definition.Fields {
+					args = append(args, record.Values[field])
+				}
+			}
+
+			query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", stage, strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+			if err := tx.Exec(query, args...).Error; err != nil {
+				return err
+			}
+		}
+
+		updates := []string{}
+		sources := []string{}
+		for _, column := range columns {
+			if column != "id" {
+				updates = append(updates, fmt.Sprintf("target.%s = source.%s", column, column))
+			}
+			sources = append(sources, "source."+column)
+		}
+
+		now := time.Now().UnixMilli()
+		query := fmt.Sprintf(`
+			MERGE %s AS target
+			USING %s AS source
+			ON target.id = source.id
+			WHEN MATCHED THEN
+				UPDATE SET %s, target.updated_at = ?, target.updated_by = ?
+			WHEN NOT MATCHED THEN
+				INSERT (%s, created_at, created_by, updated_at, updated_by)
+				VALUES (%s, ?, ?, ?, ?);
+		`, table, stage, strings.Join(updates, ", "), strings.Join(columns, ", "), strings.Join(sources, ", "))
+		actor := r.actor()
+		if err := tx.Exec(query, now, actor, now, actor, now, actor).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec("DROP TABLE " + stage).Error
+	})
+}
+
+/* Actor returns the id of the user or API key acting through the context of the repository, nil for none */
+func (r *ProcedureRepository) actor() *string {
+	return helpers.ActorID(r.db.Statement.Context)
+}
+
+func (r *ProcedureRepository) procedure(action string) string {
+	return fmt.Sprintf("%s_%s", r.definition.Procedure, action)
+}
+
+func (r *ProcedureRepository) fieldParams(id string, values Values) ([]string, []interface{}) {
+	params := []string{"@id = ?"}
+	args := []interface{}{id}
+
+	for _, field := range r.definition.Fields {
+		params = append(params, fmt.Sprintf("@%s = ?", field))
+		args = append(args, values[field])
+	}
+	return params, args
+}
@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"data-referensi/config"
+	"data-referensi/helpers"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+/* Repository is the storage backend of a single reference entity */
+type Repository interface {
+	Get(dest interface{}, query Query) error
+	GetByParent(dest interface{}, parentId string) error
+	Find(dest interface{}, id string) error
+	FindMany(dest interface{}, ids []string) error
+	Insert(id string, values Values) error
+	Update(id string, values Values) error
+	Delete(id string) error
+	Restore(id string) error
+	Count(trashed bool) int64
+	Exists(id string) (bool, error)
+	FindBy(dest interface{}, column string, values []string) error
+	FindByFold(dest interface{}, column string, values []string) error
+	Upsert(records []Record) error
+	DeleteMany(ids []string) error
+}
+
+/* Definition describes where and how an entity is stored */
+type Definition struct {
+	Table     string
+	Procedure string
+	Fields    []string
+	Search    []string
+	Parent    *ParentDefinition
+}
+
+/* Parent Definition describes the by-<parent> lookup of an entity */
+type ParentDefinition struct {
+	Column    string
+	Procedure string
+	Param     string
+}
+
+/* Query holds the list parameters shared by list, search, export and trash, ParentID scopes it to one parent */
+type Query struct {
+	Filter        string
+	SortBy        string
+	SortDirection string
+	Page          int
+	PageSize      int64
+	Trashed       bool
+	ParentID      string
+}
+
+/* Values maps a writable column to its new value */
+type Values map[string]interface{}
+
+/* Record is a row written by Upsert, inserted when its id is new and updated otherwise */
+type Record struct {
+	ID     string
+	Values Values
+}
+
+/* New returns the repository of definition for the configured backend */
+func New(db *gorm.DB, definition Definition) Repository {
+	if config.DBRepository == config.RepositoryGorm {
+		return &GormRepository{db: db, definition: definition}
+	}
+	return &ProcedureRepository{db: db, definition: definition}
+}
+
+/* Count is shared by every backend since it only needs the table */
+func count(db *gorm.DB, table string, trashed bool) int64 {
+	var count int64
+	var where string
+
+	if trashed {
+		where = "deleted_at IS NOT NULL"
+	} else {
+		where = "deleted_at IS NULL"
+	}
+
+	db.Table(table).Where(where).Count(&count)
+	return count
+}
+
+/* Exists reports whether a row with id is stored, trashed or not, shared by every backend */
+func exists(db *gorm.DB, table string, id string) (bool, error) {
+	var count int64
+
+	if err := db.Table(table).Where("id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+/* Lookup Chunk bounds the values bound in a single IN clause, SQL Server accepts at most 2100 parameters */
+const lookupChunk = 1000
+
+/* Delete Many soft deletes the untrashed rows among ids with one statement per chunk, by the actor of db, shared by every backend */
+func deleteMany(db *gorm.DB, table string, ids []string) error {
+	deletedAt := time.Now().UnixMilli()
+	for start := 0; start < len(ids); start += lookupChunk {
+		end := min(start+lookupChunk, len(ids))
+
+		err := db.Table(table).
+			Where("deleted_at IS NULL").
+			Where("id IN ?", ids[start:end]).
+			Updates(map[string]interface{}{"deleted_at": deletedAt, "deleted_by": helpers.ActorID(db.Statement.Context)}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/* Bulk Parameters bounds the values bound by one statement of a bulk write */
+const bulkParameters = 2000
+
+/* Batch Size is how many rows of columns fit in one statement, SQL Server accepts at most 1000 rows per VALUES */
+func BatchSize(columns int) int {
+	return max(1, min(1000, bulkParameters/columns))
+}
+
+/* Find By scans the active rows whose column is one of values into dest, a pointer to a slice, shared by every backend */
+func findBy(db *gorm.DB, table string, dest interface{}, column string, values []string) error {
+	return scanIn(db, table, dest, column, values)
+}
+
+/*
+Find By Fold is Find By ignoring case: IN compares case sensitively on
+Postgres and SQLite, so the lower cased column is compared to lower cased
+values.
+*/
+func findByFold(db *gorm.DB, table string, dest interface{}, column string, values []string) error {
+	folded := make([]string, len(values))
+	for i, value := range values {
+		folded[i] = strings.ToLower(value)
+	}
+	return scanIn(db, table, dest, fmt.Sprintf("LOWER(%s)", column), folded)
+}
+
+/* Scan In scans the active rows whose expression is one of values, lookupChunk values per query */
+func scanIn(db *gorm.DB, table string, dest interface{}, expression string, values []string) error {
+	rows := reflect.ValueOf(dest).Elem()
+	rows.Set(reflect.MakeSlice(rows.Type(), 0, len(values)))
+
+	for start := 0; start < len(values); start += lookupChunk {
+		end := start + lookupChunk
+		if end > len(values) {
+			end = len(values)
+		}
+
+		chunk := reflect.New(rows.Type())
+		err := db.Table(table).
+			Where("deleted_at IS NULL").
+			Where(fmt.Sprintf("%s IN ?", expression), values[start:end]).
+			Scan(chunk.Interface()).Error
+		if err != nil {
+			return err
+		}
+		rows.Set(reflect.AppendSlice(rows, chunk.Elem()))
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"data-referensi/config"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+/* Schema Report describes the drift between the database and what the repositories expect */
+type SchemaReport struct {
+	CheckedAt  int64             `json:"checked_at"`
+	Driver     string            `json:"driver"`
+	Repository string            `json:"repository"`
+	Valid      bool              `json:"valid"`
+	Tables     []TableReport     `json:"tables"`
+	Procedures []ProcedureReport `json:"procedures"`
+}
+
+type TableReport struct {
+	Name           string   `json:"name"`
+	Exists         bool     `json:"exists"`
+	MissingColumns []string `json:"missing_columns"`
+}
+
+type ProcedureReport struct {
+	Name                 string   `json:"name"`
+	Exists               bool     `json:"exists"`
+	MissingParameters    []string `json:"missing_parameters"`
+	UnexpectedParameters []string `json:"unexpected_parameters"`
+}
+
+/* Table Requirement is a table the migrations create besides the entity tables, with the columns its model maps */
+type TableRequirement struct {
+	Name    string
+	Columns []string
+}
+
+/* Procedure Requirement is a stored procedure and the parameters the repository passes to it */
+type ProcedureRequirement struct {
+	Name   string
+	Params []string
+}
+
+type procedureParameter struct {
+	ProcedureName string
+	ParameterName string
+}
+
+/* Verify Schema checks that every table, column and procedure used by definitions, and every table of tables, exists */
+func VerifySchema(db *gorm.DB, definitions []Definition, tables []TableRequirement) SchemaReport {
+	report := SchemaReport{
+		CheckedAt:  time.Now().UnixMilli(),
+		Driver:     config.DBDriver,
+		Repository: config.DBRepository,
+		Valid:      true,
+		Tables:     []TableReport{},
+		Procedures: []ProcedureReport{},
+	}
+
+	for _, definition := range definitions {
+		table := verifyTable(db, definition.Table, definition.Columns())
+		if !table.Exists || len(table.MissingColumns) > 0 {
+			report.Valid = false
+		}
+		report.Tables = append(report.Tables, table)
+	}
+	for _, requirement := range tables {
+		table := verifyTable(db, requirement.Name, requirement.Columns)
+		if !table.Exists || len(table.MissingColumns) > 0 {
+			report.Valid = false
+		}
+		report.Tables = append(report.Tables, table)
+	}
+
+	if config.DBRepository != config.RepositoryProcedure {
+		return report
+	}
+
+	requirements := []ProcedureRequirement{}
+	for _, definition := range definitions {
+		requirements = append(requirements, definition.Procedures()...)
+	}
+
+	parameters, err := procedureParameters(db, requirements)
+	if err != nil {
+		report.Valid = false
+		for _, requirement := range requirements {
+			report.Procedures = append(report.Procedures, ProcedureReport{Name: requirement.Name, MissingParameters: requirement.Params})
+		}
+		return report
+	}
+
+	for _, requirement := range requirements {
+		procedure := verifyProcedure(requirement, parameters)
+		if !procedure.Exists || len(procedure.MissingParameters) > 0 || len(procedure.UnexpectedParameters) > 0 {
+			report.Valid = false
+		}
+		report.Procedures = append(report.Procedures, procedure)
+	}
+
+	return report
+}
+
+/* Columns lists every column the repositories read or write */
+func (d Definition) Columns() []string {
+	columns := append([]string{"id"}, d.Fields...)
+	return append(columns, "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by")
+}
+
+/* Procedures lists every procedure the procedure repository calls, with its parameters */
+func (d Definition) Procedures() []ProcedureRequirement {
+	list := []string{"Filter", "SortBy", "SortDirection", "Page", "PageSize"}
+	if d.Parent != nil {
+		list = append(list, "ParentId")
+	}
+	fields := append([]string{"id"}, d.Fields...)
+
+	procedures := []ProcedureRequirement{
+		{Name: d.Procedure + "_get", Params: list},
+		{Name: d.Procedure + "_has_deleted", Params: list},
+		{Name: d.Procedure + "_get_by_id", Params: []string{"id"}},
+		{Name: d.Procedure + "_get_by_ids", Params: []string{"ids"}},
+		{Name: d.Procedure + "_insert", Params: append(append([]string{}, fields...), "created_at", "created_by", "updated_at", "updated_by")},
+		{Name: d.Procedure + "_update", Params: append(append([]string{}, fields...), "updated_at", "updated_by")},
+		{Name: d.Procedure + "_delete", Params: []string{"id", "deleted_at", "deleted_by"}},
+		{Name: d.Procedure + "_restore", Params: []string{"id", "updated_at", "updated_by"}},
+	}
+
+	if d.Parent != nil {
+		procedures = append(procedures, ProcedureRequirement{Name: d.Parent.Procedure, Params: []string{d.Parent.Param}})
+	}
+
+	return procedures
+}
+
+func verifyTable(db *gorm.DB, name string, columns []string) TableReport {
+	report := TableReport{Name: name, MissingColumns: []string{}}
+
+	migrator := db.Migrator()
+	if !migrator.HasTable(name) {
+		report.MissingColumns = columns
+		return report
+	}
+	report.Exists = true
+
+	types, err := migrator.ColumnTypes(name)
+	if err != nil {
+		report.MissingColumns = columns
+		return report
+	}
+
+	existing := map[string]bool{}
+	for _, column := range types {
+		existing[strings.ToLower(column.Name())] = true
+	}
+
+	for _, column := range columns {
+		if !existing[column] {
+			report.MissingColumns = append(report.MissingColumns, column)
+		}
+	}
+
+	return report
+}
+
+func verifyProcedure(requirement ProcedureRequirement, parameters map[string][]string) ProcedureReport {
+	report := ProcedureReport{Name: requirement.Name, MissingParameters: []string{}, UnexpectedParameters: []string{}}
+
+	existing, ok := parameters[strings.ToLower(requirement.Name)]
+	if !ok {
+		report.MissingParameters = requirement.Params
+		return report
+	}
+	report.Exists = true
+
+	expected := map[string]bool{}
+	for _, param := range requirement.Params {
+		expected[strings.ToLower(param)] = true
+	}
+
+	actual := map[string]bool{}
+	for _, param := range existing {
+		actual[param] = true
+		if !expected[param] {
+			report.UnexpectedParameters = append(report.UnexpectedParameters, param)
+		}
+	}
+
+	for _, param := range requirement.Params {
+		if !actual[strings.ToLower(param)] {
+			report.MissingParameters = append(report.MissingParameters, param)
+		}
+	}
+
+	return report
+}
+
+/* Procedure Parameters maps each existing procedure to its lower cased parameter names */
+func procedureParameters(db *gorm.DB, requirements []ProcedureRequirement) (map[string][]string, error) {
+	names := []string{}
+	for _, requirement := range requirements {
+		names = append(names, requirement.Name)
+	}
+
+	var rows []procedureParameter
+	err := db.Raw(`
+		SELECT p.name AS procedure_name, COALESCE(pa.name, '') AS parameter_name
+		FROM sys.procedures p
+		LEFT JOIN sys.parameters pa ON pa.object_id = p.object_id
+		WHERE p.name IN ?
+	`, names).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := map[string][]string{}
+	for _, row := range rows {
+		name := strings.ToLower(row.ProcedureName)
+		if _, ok := parameters[name]; !ok {
+			parameters[name] = []string{}
+		}
+		if row.ParameterName != "" {
+			parameters[name] = append(parameters[name], strings.ToLower(strings.TrimPrefix(row.ParameterName, "@")))
+		}
+	}
+
+	return parameters, nil
+}
@@ -0,0 +1,26 @@
+package requests
+
+type LoginRequest struct {
+	Username string `json:"username" validate:"required,max=100"`
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type RoleRequest struct {
+	Name        string   `json:"name" validate:"required,max=100"`
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	Permissions []string `json:"permissions" validate:"required,dive,required,max=150"`
+}
+
+type UserRolesRequest struct {
+	Roles []string `json:"roles" validate:"required,dive,required,max=100"`
+}
+
+type APIKeyRequest struct {
+	Name      string   `json:"name" validate:"required,max=255"`
+	Scopes    []string `json:"scopes" validate:"required,min=1,dive,required,max=150"`
+	ExpiresAt *int64   `json:"expires_at" validate:"omitempty,gt=0"`
+}
@@ -0,0 +1,43 @@
+package requests
+
+import (
+	"data-referensi/handlers"
+	"data-referensi/helpers"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+/* Validate Body parses the request body into R and checks its validate tags */
+func ValidateBody[R any](c *fiber.Ctx) error {
+	var req R
+	if err := c.BodyParser(&req); err != nil {
+		return handlers.SendFailed(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+
+	if errorMessages := ValidateStruct(req); len(errorMessages) > 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error":   true,
+			"message": errorMessages,
+		})
+	}
+	return c.Next()
+}
+
+/* Validate is shared so the parsed tags of each request are cached, imports validate every row */
+var validate = validator.New()
+
+/* Validate Struct returns the validation messages of req keyed by snake case field name */
+func ValidateStruct(req interface{}) map[string]string {
+	errorMessages := make(map[string]string)
+
+	if err := validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+
+		for _, fieldError := range validationErrors {
+			fieldName := helpers.ConvertCCToSC(fieldError.Field())
+			errorMessages[fieldName] = helpers.GenerateVEM(fieldName, fieldError.Tag())
+		}
+	}
+	return errorMessages
+}
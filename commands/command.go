@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"fmt"
+)
+
+/* Run executes the command named by args[0], used instead of starting the API */
+func Run(args []string) error {
+	switch args[0] {
+	case "migrate":
+		return Migrate(args[1:])
+	case "user":
+		return User(args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"data-referensi/config"
+	"data-referensi/database"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+/* Migrate runs `migrate up`, `migrate down [steps]` or `migrate status` */
+func Migrate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up|down [steps]|status")
+	}
+
+	config.ConnectDB()
+
+	switch args[0] {
+	case "up":
+		versions, err := database.MigrateUp(config.DB)
+		for _, version := range versions {
+			fmt.Printf("Migrated: %s\n", version)
+		}
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			fmt.Println("Nothing to migrate")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid steps %q", args[1])
+			}
+			steps = n
+		}
+
+		versions, err := database.MigrateDown(config.DB, steps)
+		for _, version := range versions {
+			fmt.Printf("Rolled back: %s\n", version)
+		}
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			fmt.Println("Nothing to roll back")
+		}
+	case "status":
+		statuses, err := database.MigrationStatuses(config.DB)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			if status.Applied {
+				fmt.Printf("[x] %s (%s)\n", status.Version, time.UnixMilli(status.AppliedAt).Format(time.RFC3339))
+			} else {
+				fmt.Printf("[ ] %s\n", status.Version)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown migrate action %q", args[0])
+	}
+
+	return nil
+}
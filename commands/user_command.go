@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"data-referensi/app/models"
+	"data-referensi/config"
+	"fmt"
+	"os"
+	"strings"
+)
+
+/*
+User runs `user create <username> <name>`, `user password <username>`,
+`user disable <username>`, `user enable <username>` or
+`user roles <username> [role,...]`. Passwords are read from
+USER_PASSWORD, or else from the first line of standard input.
+*/
+func User(args []string) error {
+	usage := fmt.Errorf("usage: user create <username> <name>|password <username>|disable <username>|enable <username>|roles <username> [role,...]")
+	if len(args) < 2 {
+		return usage
+	}
+
+	config.ConnectDB()
+	ctx := context.Background()
+
+	switch args[0] {
+	case "create":
+		if len(args) < 3 {
+			return usage
+		}
+		password, err := readPassword()
+		if err != nil {
+			return err
+		}
+		user, err := models.CreateUser(ctx, args[1], strings.Join(args[2:], " "), password)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created user %s (%s)\n", user.Username, user.ID)
+	case "password":
+		password, err := readPassword()
+		if err != nil {
+			return err
+		}
+		if err := models.SetUserPassword(ctx, args[1], password); err != nil {
+			return err
+		}
+		fmt.Printf("Changed the password of %s, its sessions are revoked\n", args[1])
+	case "disable", "enable":
+		if err := models.SetUserActive(ctx, args[1], args[0] == "enable"); err != nil {
+			return err
+		}
+		fmt.Printf("User %s %sd\n", args[1], args[0])
+	case "roles":
+		names := []string{}
+		if len(args) > 2 {
+			for _, name := range strings.Split(args[2], ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					names = append(names, name)
+				}
+			}
+		}
+		if err := models.SetUserRolesByName(ctx, args[1], names); err != nil {
+			return err
+		}
+		fmt.Printf("User %s has the roles %s\n", args[1], strings.Join(names, ", "))
+	default:
+		return fmt.Errorf("unknown user action %q", args[0])
+	}
+
+	return nil
+}
+
+func readPassword() (string, error) {
+	if password := os.Getenv("USER_PASSWORD"); password != "" {
+		return password, nil
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read the password: %v", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"crypto/rand"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+/* JWT Secret signs the access tokens, from JWT_SECRET */
+var JWTSecret []byte
+
+/* Access Token TTL is how long an access token is accepted, from JWT_ACCESS_TTL */
+var AccessTokenTTL = 15 * time.Minute
+
+/* Refresh Token TTL is how long a session can be refreshed after its last refresh, from JWT_REFRESH_TTL */
+var RefreshTokenTTL = 7 * 24 * time.Hour
+
+/* Public Reads lets anyone read the reference data, otherwise reads need the read permission, from PUBLIC_READS */
+var PublicReads = true
+
+/*
+Load Auth reads JWT_SECRET, JWT_ACCESS_TTL, JWT_REFRESH_TTL and PUBLIC_READS.
+JWT_SECRET is required, only JWT_RANDOM_SECRET=true, for development, lets a
+random one be generated, the tokens it signs do not survive a restart.
+*/
+func LoadAuth() {
+	JWTSecret = []byte(os.Getenv("JWT_SECRET"))
+	if len(JWTSecret) == 0 {
+		random, err := strconv.ParseBool(os.Getenv("JWT_RANDOM_SECRET"))
+		if err != nil || !random {
+			log.Fatal("No JWT_SECRET set, expected at least 32 characters, or JWT_RANDOM_SECRET=true for development")
+		}
+		log.Println("No JWT_SECRET set, using a random secret, tokens are lost on restart")
+		JWTSecret = make([]byte, 32)
+		if _, err := rand.Read(JWTSecret); err != nil {
+			log.Fatal("Failed to generate a JWT secret: ", err)
+		}
+	} else if len(JWTSecret) < 32 {
+		log.Fatal("Invalid JWT_SECRET, expected at least 32 characters")
+	}
+
+	AccessTokenTTL = positiveDuration("JWT_ACCESS_TTL", AccessTokenTTL)
+	RefreshTokenTTL = positiveDuration("JWT_REFRESH_TTL", RefreshTokenTTL)
+
+	if value := os.Getenv("PUBLIC_READS"); value != "" {
+		public, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Fatalf("Invalid PUBLIC_READS %q, expected true or false", value)
+		}
+		PublicReads = public
+	}
+}
+
+func positiveDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Fatalf("Invalid %s %q, expected a positive duration such as 15m", key, value)
+	}
+	return duration
+}
@@ -5,13 +5,37 @@ import (
 	"log"
 	"os"
 
+	"github.com/glebarez/sqlite"
 	"github.com/joho/godotenv"
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlserver"
 	"gorm.io/gorm"
 )
 
+const (
+	DriverSQLServer = "sqlserver"
+	DriverSQLite    = "sqlite"
+	DriverPostgres  = "postgres"
+
+	RepositoryProcedure = "procedure"
+	RepositoryGorm      = "gorm"
+
+	SchemaCheckStrict = "strict"
+	SchemaCheckReport = "report"
+	SchemaCheckOff    = "off"
+)
+
 var DB *gorm.DB
 
+/* DB Driver is the configured database driver, one of the Driver* constants */
+var DBDriver string
+
+/* DB Repository is the storage backend used by the models, one of the Repository* constants */
+var DBRepository string
+
+/* Schema Check decides what happens on boot when the schema drifts, one of the SchemaCheck* constants */
+var SchemaCheck string
+
 func ConnectDB() {
 	var err error
 
@@ -19,19 +43,67 @@ func ConnectDB() {
 		log.Println("No .env file found, using system environment variables")
 	}
 
-	username := os.Getenv("DB_USERNAME")
-	password := os.Getenv("DB_PASSWORD")
-	host := os.Getenv("DB_HOST")
-	port := os.Getenv("DB_PORT")
-	database := os.Getenv("DB_NAME")
+	DBDriver = os.Getenv("DB_DRIVER")
+	if DBDriver == "" {
+		DBDriver = DriverSQLServer
+	}
+
+	DBRepository = os.Getenv("DB_REPOSITORY")
+	if DBRepository == "" {
+		if DBDriver == DriverSQLServer {
+			DBRepository = RepositoryProcedure
+		} else {
+			DBRepository = RepositoryGorm
+		}
+	}
+
+	SchemaCheck = os.Getenv("SCHEMA_CHECK")
+	if SchemaCheck == "" {
+		SchemaCheck = SchemaCheckReport
+	}
+
+	LoadTimeouts()
+	LoadJobs()
+	LoadAuth()
+
+	if DBRepository == RepositoryProcedure && DBDriver != DriverSQLServer {
+		log.Fatalf("The %s repository requires the %s driver", RepositoryProcedure, DriverSQLServer)
+	}
 
-	dsn := fmt.Sprintf("sqlserver://%s:%s@%s:%s?database=%s", username, password, host, port, database)
+	dialector, err := dialect()
+	if err != nil {
+		log.Fatal("Failed to connect to database: ", err)
+	}
 
-	DB, err = gorm.Open(sqlserver.Open(dsn), &gorm.Config{})
+	DB, err = gorm.Open(dialector, &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatal("Failed to connect to database: ", err)
 		os.Exit(1)
 	}
 
-	log.Println("Database connected successfully!")
+	log.Printf("Database connected successfully! (driver: %s, repository: %s)", DBDriver, DBRepository)
+}
+
+func dialect() (gorm.Dialector, error) {
+	username := os.Getenv("DB_USERNAME")
+	password := os.Getenv("DB_PASSWORD")
+	host := os.Getenv("DB_HOST")
+	port := os.Getenv("DB_PORT")
+	database := os.Getenv("DB_NAME")
+
+	switch DBDriver {
+	case DriverSQLServer:
+		dsn := fmt.Sprintf("sqlserver://%s:%s@%s:%s?database=%s", username, password, host, port, database)
+		return sqlserver.Open(dsn), nil
+	case DriverPostgres:
+		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable", host, username, password, database, port)
+		return postgres.Open(dsn), nil
+	case DriverSQLite:
+		if database == "" {
+			database = "data-referensi.db"
+		}
+		return sqlite.Open(database), nil
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q", DBDriver)
+	}
 }
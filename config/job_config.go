@@ -0,0 +1,44 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+/* Job Workers is the number of background jobs run at once, from JOB_WORKERS */
+var JobWorkers = 2
+
+/* Job Queue is the number of jobs that may wait for a worker, from JOB_QUEUE */
+var JobQueue = 100
+
+/* Job Retention is how long a finished job and its file are kept, from JOB_RETENTION */
+var JobRetention = 24 * time.Hour
+
+/* Load Jobs reads JOB_WORKERS, JOB_QUEUE and JOB_RETENTION */
+func LoadJobs() {
+	JobWorkers = positiveInt("JOB_WORKERS", JobWorkers)
+	JobQueue = positiveInt("JOB_QUEUE", JobQueue)
+
+	if value := os.Getenv("JOB_RETENTION"); value != "" {
+		retention, err := time.ParseDuration(value)
+		if err != nil || retention <= 0 {
+			log.Fatalf("Invalid JOB_RETENTION %q, expected a positive duration such as 24h", value)
+		}
+		JobRetention = retention
+	}
+}
+
+func positiveInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		log.Fatalf("Invalid %s %q, expected a positive number", key, value)
+	}
+	return number
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	OperationList   = "list"
+	OperationWrite  = "write"
+	OperationExport = "export"
+	OperationImport = "import"
+)
+
+/* Timeouts holds the database deadline of every operation, overridable through DB_TIMEOUT_<OPERATION> */
+var Timeouts = map[string]time.Duration{
+	OperationList:   30 * time.Second,
+	OperationWrite:  30 * time.Second,
+	OperationExport: 5 * time.Minute,
+	OperationImport: 10 * time.Minute,
+}
+
+/* Load Timeouts reads the DB_TIMEOUT_* variables, each a Go duration such as 45s or 2m */
+func LoadTimeouts() {
+	for operation := range Timeouts {
+		key := "DB_TIMEOUT_" + strings.ToUpper(operation)
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			log.Fatalf("Invalid %s %q, expected a positive duration such as 30s", key, value)
+		}
+		Timeouts[operation] = timeout
+	}
+}
+
+/* Timeout returns the deadline of an operation, falling back to the list deadline */
+func Timeout(operation string) time.Duration {
+	if timeout, ok := Timeouts[operation]; ok {
+		return timeout
+	}
+	return Timeouts[OperationList]
+}
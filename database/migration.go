@@ -0,0 +1,244 @@
+package database
+
+import (
+	"data-referensi/config"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+/*
+Migrations are embedded per dialect: migrations/sqlserver holds the tables
+and the sp_mst_* procedures, migrations/default holds the plain tables used
+by the GORM repository on SQLite and Postgres. Every file is split into
+batches on lines containing only GO, as CREATE PROCEDURE must be alone in
+its batch on SQL Server.
+*/
+//go:embed migrations
+var migrationFiles embed.FS
+
+var batchSeparator = regexp.MustCompile(`(?m)^\s*GO\s*$`)
+
+const migrationTable = "schema_migrations"
+
+type Migration struct {
+	Version string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   string `json:"version"`
+	Applied   bool   `json:"applied"`
+	AppliedAt int64  `json:"applied_at"`
+}
+
+type appliedMigration struct {
+	Version   string
+	AppliedAt int64
+}
+
+/* Load Migrations reads the embedded migrations of the configured driver, ordered by version */
+func LoadMigrations() ([]Migration, error) {
+	dir := path.Join("migrations", dialect())
+
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %v", err)
+	}
+
+	migrations := map[string]*Migration{}
+	for _, entry := range entries {
+		name := entry.Name()
+		content, err := fs.ReadFile(migrationFiles, path.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %v", name, err)
+		}
+
+		var version string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			version = strings.TrimSuffix(name, ".up.sql")
+		case strings.HasSuffix(name, ".down.sql"):
+			version = strings.TrimSuffix(name, ".down.sql")
+		default:
+			continue
+		}
+
+		if migrations[version] == nil {
+			migrations[version] = &Migration{Version: version}
+		}
+		if strings.HasSuffix(name, ".up.sql") {
+			migrations[version].Up = string(content)
+		} else {
+			migrations[version].Down = string(content)
+		}
+	}
+
+	result := []Migration{}
+	for _, migration := range migrations {
+		result = append(result, *migration)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+
+	return result, nil
+}
+
+/* Migrate Up applies every pending migration and returns the applied versions */
+func MigrateUp(db *gorm.DB) ([]string, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []string{}
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := execBatches(tx, migration.Up); err != nil {
+				return err
+			}
+			return tx.Table(migrationTable).Create(map[string]interface{}{
+				"version":    migration.Version,
+				"applied_at": time.Now().UnixMilli(),
+			}).Error
+		})
+		if err != nil {
+			return versions, fmt.Errorf("migration %s failed: %v", migration.Version, err)
+		}
+
+		versions = append(versions, migration.Version)
+	}
+
+	return versions, nil
+}
+
+/* Migrate Down rolls back the last steps applied migrations and returns the rolled back versions */
+func MigrateDown(db *gorm.DB, steps int) ([]string, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []string{}
+	for i := len(migrations) - 1; i >= 0 && len(versions) < steps; i-- {
+		migration := migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := execBatches(tx, migration.Down); err != nil {
+				return err
+			}
+			return tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE version = ?", migrationTable), migration.Version).Error
+		})
+		if err != nil {
+			return versions, fmt.Errorf("rollback of %s failed: %v", migration.Version, err)
+		}
+
+		versions = append(versions, migration.Version)
+	}
+
+	return versions, nil
+}
+
+/* Migration Statuses lists every known migration and whether it has been applied */
+func MigrationStatuses(db *gorm.DB) ([]MigrationStatus, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []MigrationStatus{}
+	for _, migration := range migrations {
+		appliedAt, ok := applied[migration.Version]
+		statuses = append(statuses, MigrationStatus{Version: migration.Version, Applied: ok, AppliedAt: appliedAt})
+	}
+
+	return statuses, nil
+}
+
+func appliedMigrations(db *gorm.DB) (map[string]int64, error) {
+	if err := ensureMigrationTable(db); err != nil {
+		return nil, err
+	}
+
+	var rows []appliedMigration
+	if err := db.Table(migrationTable).Select("version, applied_at").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := map[string]int64{}
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+	return applied, nil
+}
+
+func ensureMigrationTable(db *gorm.DB) error {
+	if db.Migrator().HasTable(migrationTable) {
+		return nil
+	}
+
+	return db.Exec(fmt.Sprintf(`
+		CREATE TABLE %s (
+			version VARCHAR(255) NOT NULL PRIMARY KEY,
+			applied_at BIGINT NOT NULL
+		)
+	`, migrationTable)).Error
+}
+
+func execBatches(db *gorm.DB, script string) error {
+	for _, batch := range batchSeparator.Split(script, -1) {
+		if strings.TrimSpace(stripComments(batch)) == "" {
+			continue
+		}
+		if err := db.Exec(batch).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func stripComments(batch string) string {
+	lines := []string{}
+	for _, line := range strings.Split(batch, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func dialect() string {
+	if config.DBDriver == config.DriverSQLServer {
+		return "sqlserver"
+	}
+	return "default"
+}
@@ -0,0 +1,16 @@
+CREATE TABLE mst_countries (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	phone_code VARCHAR(10) NOT NULL,
+	icon_flag_path VARCHAR(255) NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_countries_name ON mst_countries (name) WHERE deleted_at IS NULL;
+GO
@@ -0,0 +1,20 @@
+CREATE TABLE mst_provinces (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	country_id VARCHAR(36) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	code VARCHAR(5) NOT NULL,
+	region_code VARCHAR(255) NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_provinces_code ON mst_provinces (code) WHERE deleted_at IS NULL;
+GO
+
+CREATE INDEX ix_mst_provinces_country_id ON mst_provinces (country_id);
+GO
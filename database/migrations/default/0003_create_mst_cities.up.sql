@@ -0,0 +1,19 @@
+CREATE TABLE mst_cities (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	province_id VARCHAR(36) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	code VARCHAR(10) NOT NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_cities_code ON mst_cities (code) WHERE deleted_at IS NULL;
+GO
+
+CREATE INDEX ix_mst_cities_province_id ON mst_cities (province_id);
+GO
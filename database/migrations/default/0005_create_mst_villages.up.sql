@@ -0,0 +1,19 @@
+CREATE TABLE mst_villages (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	district_id VARCHAR(36) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	code VARCHAR(20) NOT NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_villages_code ON mst_villages (code) WHERE deleted_at IS NULL;
+GO
+
+CREATE INDEX ix_mst_villages_district_id ON mst_villages (district_id);
+GO
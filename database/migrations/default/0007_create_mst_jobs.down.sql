@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS mst_jobs;
+GO
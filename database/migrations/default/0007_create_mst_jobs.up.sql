@@ -0,0 +1,16 @@
+CREATE TABLE mst_jobs (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	code VARCHAR(10) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	description VARCHAR(255) NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_jobs_code ON mst_jobs (code) WHERE deleted_at IS NULL;
+GO
@@ -0,0 +1,18 @@
+CREATE TABLE mst_almamater_sizes (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	code VARCHAR(50) NOT NULL,
+	size VARCHAR(255) NOT NULL,
+	chest_size VARCHAR(255) NOT NULL,
+	arm_length VARCHAR(255) NOT NULL,
+	body_length VARCHAR(255) NOT NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_almamater_sizes_code ON mst_almamater_sizes (code) WHERE deleted_at IS NULL;
+GO
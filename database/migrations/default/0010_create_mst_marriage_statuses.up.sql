@@ -0,0 +1,14 @@
+CREATE TABLE mst_marriage_statuses (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_marriage_statuses_name ON mst_marriage_statuses (name) WHERE deleted_at IS NULL;
+GO
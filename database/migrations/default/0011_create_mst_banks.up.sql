@@ -0,0 +1,15 @@
+CREATE TABLE mst_banks (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	code VARCHAR(12) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_banks_code ON mst_banks (code) WHERE deleted_at IS NULL;
+GO
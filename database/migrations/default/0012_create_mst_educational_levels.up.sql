@@ -0,0 +1,16 @@
+CREATE TABLE mst_educational_levels (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	code VARCHAR(3) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	description VARCHAR(255) NOT NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_educational_levels_code ON mst_educational_levels (code) WHERE deleted_at IS NULL;
+GO
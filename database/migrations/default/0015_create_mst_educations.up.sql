@@ -0,0 +1,22 @@
+CREATE TABLE mst_educations (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	educational_level_id VARCHAR(36) NOT NULL,
+	study_program_id VARCHAR(36) NULL,
+	name VARCHAR(255) NOT NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_educations_educational_level_id_name ON mst_educations (educational_level_id, name) WHERE deleted_at IS NULL;
+GO
+
+CREATE INDEX ix_mst_educations_educational_level_id ON mst_educations (educational_level_id);
+GO
+
+CREATE INDEX ix_mst_educations_study_program_id ON mst_educations (study_program_id);
+GO
@@ -0,0 +1,32 @@
+CREATE TABLE import_batches (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	entity VARCHAR(100) NOT NULL,
+	table_name VARCHAR(100) NOT NULL,
+	file_name VARCHAR(255) NULL,
+	mode VARCHAR(10) NOT NULL,
+	total INT NOT NULL,
+	inserted INT NOT NULL,
+	updated INT NOT NULL,
+	unchanged INT NOT NULL,
+	failed INT NOT NULL,
+	created_at BIGINT NOT NULL,
+	created_by VARCHAR(36) NULL,
+	reverted_at BIGINT NULL,
+	reverted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE INDEX ix_import_batches_table_name_created_at ON import_batches (table_name, created_at);
+GO
+
+CREATE TABLE import_batch_rows (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	batch_id VARCHAR(36) NOT NULL,
+	record_id VARCHAR(36) NOT NULL,
+	action VARCHAR(10) NOT NULL,
+	previous_values TEXT NULL
+);
+GO
+
+CREATE INDEX ix_import_batch_rows_batch_id ON import_batch_rows (batch_id);
+GO
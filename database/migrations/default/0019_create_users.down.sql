@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS user_sessions;
+GO
+
+DROP TABLE IF EXISTS users;
+GO
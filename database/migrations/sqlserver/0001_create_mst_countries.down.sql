@@ -0,0 +1,23 @@
+DROP PROCEDURE IF EXISTS sp_mst_countries_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_countries_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_countries_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_countries_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_countries_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_countries_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_countries_restore;
+GO
+
+DROP TABLE IF EXISTS mst_countries;
+GO
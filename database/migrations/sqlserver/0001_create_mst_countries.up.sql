@@ -0,0 +1,157 @@
+-- mst_countries and its sp_mst_countries_* procedures
+
+CREATE TABLE mst_countries (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	name NVARCHAR(255) NOT NULL,
+	phone_code NVARCHAR(10) NOT NULL,
+	icon_flag_path NVARCHAR(255) NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_countries_name ON mst_countries (name) WHERE deleted_at IS NULL;
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_countries_get
+	@Filter NVARCHAR(255) = '',
+	@SortBy NVARCHAR(50) = 'name',
+	@SortDirection NVARCHAR(4) = 'asc',
+	@Page INT = 1,
+	@PageSize BIGINT = 10
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	IF @SortBy NOT IN ('id', 'name', 'phone_code', 'icon_flag_path', 'created_at', 'updated_at') SET @SortBy = 'name';
+	IF LOWER(@SortDirection) <> 'desc' SET @SortDirection = 'asc';
+	IF @Page < 1 SET @Page = 1;
+	IF @PageSize < 1 SET @PageSize = 1;
+
+	DECLARE @sql NVARCHAR(MAX) = N'
+		SELECT id, name, phone_code, icon_flag_path, created_at, updated_at
+		FROM mst_countries
+		WHERE deleted_at IS NULL
+		AND (@Filter = '''' OR name LIKE ''%'' + @Filter + ''%'')
+		ORDER BY ' + QUOTENAME(@SortBy) + N' ' + @SortDirection + N'
+		OFFSET (@Page - 1) * @PageSize ROWS
+		FETCH NEXT @PageSize ROWS ONLY';
+
+	EXEC sp_executesql @sql,
+		N'@Filter NVARCHAR(255), @Page INT, @PageSize BIGINT',
+		@Filter, @Page, @PageSize;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_countries_has_deleted
+	@Filter NVARCHAR(255) = '',
+	@SortBy NVARCHAR(50) = 'name',
+	@SortDirection NVARCHAR(4) = 'asc',
+	@Page INT = 1,
+	@PageSize BIGINT = 10
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	IF @SortBy NOT IN ('id', 'name', 'phone_code', 'icon_flag_path', 'created_at', 'updated_at') SET @SortBy = 'name';
+	IF LOWER(@SortDirection) <> 'desc' SET @SortDirection = 'asc';
+	IF @Page < 1 SET @Page = 1;
+	IF @PageSize < 1 SET @PageSize = 1;
+
+	DECLARE @sql NVARCHAR(MAX) = N'
+		SELECT id, name, phone_code, icon_flag_path, created_at, updated_at, deleted_at
+		FROM mst_countries
+		WHERE deleted_at IS NOT NULL
+		AND (@Filter = '''' OR name LIKE ''%'' + @Filter + ''%'')
+		ORDER BY ' + QUOTENAME(@SortBy) + N' ' + @SortDirection + N'
+		OFFSET (@Page - 1) * @PageSize ROWS
+		FETCH NEXT @PageSize ROWS ONLY';
+
+	EXEC sp_executesql @sql,
+		N'@Filter NVARCHAR(255), @Page INT, @PageSize BIGINT',
+		@Filter, @Page, @PageSize;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_countries_get_by_id
+	@id VARCHAR(36)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, name, phone_code, icon_flag_path, created_at, updated_at
+	FROM mst_countries
+	WHERE id = @id;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_countries_insert
+	@id VARCHAR(36),
+	@name NVARCHAR(255),
+	@phone_code NVARCHAR(10),
+	@icon_flag_path NVARCHAR(255),
+	@created_at BIGINT,
+	@created_by VARCHAR(36) = NULL,
+	@updated_at BIGINT,
+	@updated_by VARCHAR(36) = NULL
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	INSERT INTO mst_countries (id, name, phone_code, icon_flag_path, created_at, created_by, updated_at, updated_by)
+	VALUES (@id, @name, @phone_code, @icon_flag_path, @created_at, @created_by, @updated_at, @updated_by);
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_countries_update
+	@id VARCHAR(36),
+	@name NVARCHAR(255),
+	@phone_code NVARCHAR(10),
+	@icon_flag_path NVARCHAR(255),
+	@updated_at BIGINT,
+	@updated_by VARCHAR(36) = NULL
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	UPDATE mst_countries SET
+		name = @name,
+		phone_code = @phone_code,
+		icon_flag_path = @icon_flag_path,
+		updated_at = @updated_at,
+		updated_by = @updated_by
+	WHERE id = @id;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_countries_delete
+	@id VARCHAR(36),
+	@deleted_at BIGINT,
+	@deleted_by VARCHAR(36) = NULL
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	UPDATE mst_countries SET
+		deleted_at = @deleted_at,
+		deleted_by = @deleted_by
+	WHERE id = @id;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_countries_restore
+	@id VARCHAR(36)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	UPDATE mst_countries SET
+		deleted_at = NULL,
+		deleted_by = NULL
+	WHERE id = @id;
+END
+GO
@@ -0,0 +1,26 @@
+DROP PROCEDURE IF EXISTS sp_mst_provinces_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_provinces_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_provinces_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_provinces_get_by_country_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_provinces_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_provinces_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_provinces_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_provinces_restore;
+GO
+
+DROP TABLE IF EXISTS mst_provinces;
+GO
@@ -0,0 +1,178 @@
+-- mst_provinces and its sp_mst_provinces_* procedures
+
+CREATE TABLE mst_provinces (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	country_id VARCHAR(36) NOT NULL,
+	name NVARCHAR(255) NOT NULL,
+	code NVARCHAR(5) NOT NULL,
+	region_code NVARCHAR(255) NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_provinces_code ON mst_provinces (code) WHERE deleted_at IS NULL;
+GO
+
+CREATE INDEX ix_mst_provinces_country_id ON mst_provinces (country_id);
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_provinces_get
+	@Filter NVARCHAR(255) = '',
+	@SortBy NVARCHAR(50) = 'name',
+	@SortDirection NVARCHAR(4) = 'asc',
+	@Page INT = 1,
+	@PageSize BIGINT = 10
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	IF @SortBy NOT IN ('id', 'country_id', 'name', 'code', 'region_code', 'created_at', 'updated_at') SET @SortBy = 'name';
+	IF LOWER(@SortDirection) <> 'desc' SET @SortDirection = 'asc';
+	IF @Page < 1 SET @Page = 1;
+	IF @PageSize < 1 SET @PageSize = 1;
+
+	DECLARE @sql NVARCHAR(MAX) = N'
+		SELECT id, country_id, name, code, region_code, created_at, updated_at
+		FROM mst_provinces
+		WHERE deleted_at IS NULL
+		AND (@Filter = '''' OR code LIKE ''%'' + @Filter + ''%'' OR name LIKE ''%'' + @Filter + ''%'')
+		ORDER BY ' + QUOTENAME(@SortBy) + N' ' + @SortDirection + N'
+		OFFSET (@Page - 1) * @PageSize ROWS
+		FETCH NEXT @PageSize ROWS ONLY';
+
+	EXEC sp_executesql @sql,
+		N'@Filter NVARCHAR(255), @Page INT, @PageSize BIGINT',
+		@Filter, @Page, @PageSize;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_provinces_has_deleted
+	@Filter NVARCHAR(255) = '',
+	@SortBy NVARCHAR(50) = 'name',
+	@SortDirection NVARCHAR(4) = 'asc',
+	@Page INT = 1,
+	@PageSize BIGINT = 10
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	IF @SortBy NOT IN ('id', 'country_id', 'name', 'code', 'region_code', 'created_at', 'updated_at') SET @SortBy = 'name';
+	IF LOWER(@SortDirection) <> 'desc' SET @SortDirection = 'asc';
+	IF @Page < 1 SET @Page = 1;
+	IF @PageSize < 1 SET @PageSize = 1;
+
+	DECLARE @sql NVARCHAR(MAX) = N'
+		SELECT id, country_id, name, code, region_code, created_at, updated_at, deleted_at
+		FROM mst_provinces
+		WHERE deleted_at IS NOT NULL
+		AND (@Filter = '''' OR code LIKE ''%'' + @Filter + ''%'' OR name LIKE ''%'' + @Filter + ''%'')
+		ORDER BY ' + QUOTENAME(@SortBy) + N' ' + @SortDirection + N'
+		OFFSET (@Page - 1) * @PageSize ROWS
+		FETCH NEXT @PageSize ROWS ONLY';
+
+	EXEC sp_executesql @sql,
+		N'@Filter NVARCHAR(255), @Page INT, @PageSize BIGINT',
+		@Filter, @Page, @PageSize;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_provinces_get_by_id
+	@id VARCHAR(36)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, country_id, name, code, region_code, created_at, updated_at
+	FROM mst_provinces
+	WHERE id = @id;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_provinces_get_by_country_id
+	@country_id VARCHAR(36)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, code, name
+	FROM mst_provinces
+	WHERE country_id = @country_id
+	AND deleted_at IS NULL
+	ORDER BY name;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_provinces_insert
+	@id VARCHAR(36),
+	@country_id VARCHAR(36),
+	@name NVARCHAR(255),
+	@code NVARCHAR(5),
+	@region_code NVARCHAR(255),
+	@created_at BIGINT,
+	@created_by VARCHAR(36) = NULL,
+	@updated_at BIGINT,
+	@updated_by VARCHAR(36) = NULL
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	INSERT INTO mst_provinces (id, country_id, name, code, region_code, created_at, created_by, updated_at, updated_by)
+	VALUES (@id, @country_id, @name, @code, @region_code, @created_at, @created_by, @updated_at, @updated_by);
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_provinces_update
+	@id VARCHAR(36),
+	@country_id VARCHAR(36),
+	@name NVARCHAR(255),
+	@code NVARCHAR(5),
+	@region_code NVARCHAR(255),
+	@updated_at BIGINT,
+	@updated_by VARCHAR(36) = NULL
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	UPDATE mst_provinces SET
+		country_id = @country_id,
+		name = @name,
+		code = @code,
+		region_code = @region_code,
+		updated_at = @updated_at,
+		updated_by = @updated_by
+	WHERE id = @id;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_provinces_delete
+	@id VARCHAR(36),
+	@deleted_at BIGINT,
+	@deleted_by VARCHAR(36) = NULL
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	UPDATE mst_provinces SET
+		deleted_at = @deleted_at,
+		deleted_by = @deleted_by
+	WHERE id = @id;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_provinces_restore
+	@id VARCHAR(36)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	UPDATE mst_provinces SET
+		deleted_at = NULL,
+		deleted_by = NULL
+	WHERE id = @id;
+END
+GO
@@ -0,0 +1,26 @@
+DROP PROCEDURE IF EXISTS sp_mst_cities_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_cities_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_cities_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_cities_get_by_province_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_cities_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_cities_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_cities_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_cities_restore;
+GO
+
+DROP TABLE IF EXISTS mst_cities;
+GO
@@ -0,0 +1,174 @@
+-- mst_cities and its sp_mst_cities_* procedures
+
+CREATE TABLE mst_cities (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	province_id VARCHAR(36) NOT NULL,
+	name NVARCHAR(255) NOT NULL,
+	code NVARCHAR(10) NOT NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_cities_code ON mst_cities (code) WHERE deleted_at IS NULL;
+GO
+
+CREATE INDEX ix_mst_cities_province_id ON mst_cities (province_id);
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_cities_get
+	@Filter NVARCHAR(255) = '',
+	@SortBy NVARCHAR(50) = 'name',
+	@SortDirection NVARCHAR(4) = 'asc',
+	@Page INT = 1,
+	@PageSize BIGINT = 10
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	IF @SortBy NOT IN ('id', 'province_id', 'name', 'code', 'created_at', 'updated_at') SET @SortBy = 'name';
+	IF LOWER(@SortDirection) <> 'desc' SET @SortDirection = 'asc';
+	IF @Page < 1 SET @Page = 1;
+	IF @PageSize < 1 SET @PageSize = 1;
+
+	DECLARE @sql NVARCHAR(MAX) = N'
+		SELECT id, province_id, name, code, created_at, updated_at
+		FROM mst_cities
+		WHERE deleted_at IS NULL
+		AND (@Filter = '''' OR code LIKE ''%'' + @Filter + ''%'' OR name LIKE ''%'' + @Filter + ''%'')
+		ORDER BY ' + QUOTENAME(@SortBy) + N' ' + @SortDirection + N'
+		OFFSET (@Page - 1) * @PageSize ROWS
+		FETCH NEXT @PageSize ROWS ONLY';
+
+	EXEC sp_executesql @sql,
+		N'@Filter NVARCHAR(255), @Page INT, @PageSize BIGINT',
+		@Filter, @Page, @PageSize;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_cities_has_deleted
+	@Filter NVARCHAR(255) = '',
+	@SortBy NVARCHAR(50) = 'name',
+	@SortDirection NVARCHAR(4) = 'asc',
+	@Page INT = 1,
+	@PageSize BIGINT = 10
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	IF @SortBy NOT IN ('id', 'province_id', 'name', 'code', 'created_at', 'updated_at') SET @SortBy = 'name';
+	IF LOWER(@SortDirection) <> 'desc' SET @SortDirection = 'asc';
+	IF @Page < 1 SET @Page = 1;
+	IF @PageSize < 1 SET @PageSize = 1;
+
+	DECLARE @sql NVARCHAR(MAX) = N'
+		SELECT id, province_id, name, code, created_at, updated_at, deleted_at
+		FROM mst_cities
+		WHERE deleted_at IS NOT NULL
+		AND (@Filter = '''' OR code LIKE ''%'' + @Filter + ''%'' OR name LIKE ''%'' + @Filter + ''%'')
+		ORDER BY ' + QUOTENAME(@SortBy) + N' ' + @SortDirection + N'
+		OFFSET (@Page - 1) * @PageSize ROWS
+		FETCH NEXT @PageSize ROWS ONLY';
+
+	EXEC sp_executesql @sql,
+		N'@Filter NVARCHAR(255), @Page INT, @PageSize BIGINT',
+		@Filter, @Page, @PageSize;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_cities_get_by_id
+	@id VARCHAR(36)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, province_id, name, code, created_at, updated_at
+	FROM mst_cities
+	WHERE id = @id;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_cities_get_by_province_id
+	@province_id VARCHAR(36)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, code, name
+	FROM mst_cities
+	WHERE province_id = @province_id
+	AND deleted_at IS NULL
+	ORDER BY name;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_cities_insert
+	@id VARCHAR(36),
+	@province_id VARCHAR(36),
+	@name NVARCHAR(255),
+	@code NVARCHAR(10),
+	@created_at BIGINT,
+	@created_by VARCHAR(36) = NULL,
+	@updated_at BIGINT,
+	@updated_by VARCHAR(36) = NULL
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	INSERT INTO mst_cities (id, province_id, name, code, created_at, created_by, updated_at, updated_by)
+	VALUES (@id, @province_id, @name, @code, @created_at, @created_by, @updated_at, @updated_by);
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_cities_update
+	@id VARCHAR(36),
+	@province_id VARCHAR(36),
+	@name NVARCHAR(255),
+	@code NVARCHAR(10),
+	@updated_at BIGINT,
+	@updated_by VARCHAR(36) = NULL
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	UPDATE mst_cities SET
+		province_id = @province_id,
+		name = @name,
+		code = @code,
+		updated_at = @updated_at,
+		updated_by = @updated_by
+	WHERE id = @id;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_cities_delete
+	@id VARCHAR(36),
+	@deleted_at BIGINT,
+	@deleted_by VARCHAR(36) = NULL
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	UPDATE mst_cities SET
+		deleted_at = @deleted_at,
+		deleted_by = @deleted_by
+	WHERE id = @id;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_cities_restore
+	@id VARCHAR(36)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	UPDATE mst_cities SET
+		deleted_at = NULL,
+		deleted_by = NULL
+	WHERE id = @id;
+END
+GO
@@ -0,0 +1,26 @@
+DROP PROCEDURE IF EXISTS sp_mst_districts_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_districts_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_districts_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_districts_get_by_city_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_districts_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_districts_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_districts_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_districts_restore;
+GO
+
+DROP TABLE IF EXISTS mst_districts;
+GO
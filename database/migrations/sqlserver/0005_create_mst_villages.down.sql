@@ -0,0 +1,26 @@
+DROP PROCEDURE IF EXISTS sp_mst_villages_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_villages_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_villages_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_villages_get_by_district_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_villages_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_villages_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_villages_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_villages_restore;
+GO
+
+DROP TABLE IF EXISTS mst_villages;
+GO
@@ -0,0 +1,23 @@
+DROP PROCEDURE IF EXISTS sp_mst_religions_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_religions_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_religions_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_religions_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_religions_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_religions_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_religions_restore;
+GO
+
+DROP TABLE IF EXISTS mst_religions;
+GO
@@ -0,0 +1,23 @@
+DROP PROCEDURE IF EXISTS sp_mst_jobs_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_jobs_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_jobs_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_jobs_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_jobs_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_jobs_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_jobs_restore;
+GO
+
+DROP TABLE IF EXISTS mst_jobs;
+GO
@@ -0,0 +1,23 @@
+DROP PROCEDURE IF EXISTS sp_mst_ethnics_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_ethnics_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_ethnics_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_ethnics_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_ethnics_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_ethnics_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_ethnics_restore;
+GO
+
+DROP TABLE IF EXISTS mst_ethnics;
+GO
@@ -0,0 +1,23 @@
+DROP PROCEDURE IF EXISTS sp_mst_almamater_sizes_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_almamater_sizes_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_almamater_sizes_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_almamater_sizes_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_almamater_sizes_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_almamater_sizes_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_almamater_sizes_restore;
+GO
+
+DROP TABLE IF EXISTS mst_almamater_sizes;
+GO
@@ -0,0 +1,165 @@
+-- mst_almamater_sizes and its sp_mst_almamater_sizes_* procedures
+
+CREATE TABLE mst_almamater_sizes (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	code NVARCHAR(50) NOT NULL,
+	size NVARCHAR(255) NOT NULL,
+	chest_size NVARCHAR(255) NOT NULL,
+	arm_length NVARCHAR(255) NOT NULL,
+	body_length NVARCHAR(255) NOT NULL,
+	created_at BIGINT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NULL,
+	updated_by VARCHAR(36) NULL,
+	deleted_at BIGINT NULL,
+	deleted_by VARCHAR(36) NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_mst_almamater_sizes_code ON mst_almamater_sizes (code) WHERE deleted_at IS NULL;
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_almamater_sizes_get
+	@Filter NVARCHAR(255) = '',
+	@SortBy NVARCHAR(50) = 'name',
+	@SortDirection NVARCHAR(4) = 'asc',
+	@Page INT = 1,
+	@PageSize BIGINT = 10
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	IF @SortBy NOT IN ('id', 'code', 'size', 'chest_size', 'arm_length', 'body_length', 'created_at', 'updated_at') SET @SortBy = 'code';
+	IF LOWER(@SortDirection) <> 'desc' SET @SortDirection = 'asc';
+	IF @Page < 1 SET @Page = 1;
+	IF @PageSize < 1 SET @PageSize = 1;
+
+	DECLARE @sql NVARCHAR(MAX) = N'
+		SELECT id, code, size, chest_size, arm_length, body_length, created_at, updated_at
+		FROM mst_almamater_sizes
+		WHERE deleted_at IS NULL
+		AND (@Filter = '''' OR code LIKE ''%'' + @Filter + ''%'' OR size LIKE ''%'' + @Filter + ''%'')
+		ORDER BY ' + QUOTENAME(@SortBy) + N' ' + @SortDirection + N'
+		OFFSET (@Page - 1) * @PageSize ROWS
+		FETCH NEXT @PageSize ROWS ONLY';
+
+	EXEC sp_executesql @sql,
+		N'@Filter NVARCHAR(255), @Page INT, @PageSize BIGINT',
+		@Filter, @Page, @PageSize;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_almamater_sizes_has_deleted
+	@Filter NVARCHAR(255) = '',
+	@SortBy NVARCHAR(50) = 'name',
+	@SortDirection NVARCHAR(4) = 'asc',
+	@Page INT = 1,
+	@PageSize BIGINT = 10
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	IF @SortBy NOT IN ('id', 'code', 'size', 'chest_size', 'arm_length', 'body_length', 'created_at', 'updated_at') SET @SortBy = 'code';
+	IF LOWER(@SortDirection) <> 'desc' SET @SortDirection = 'asc';
+	IF @Page < 1 SET @Page = 1;
+	IF @PageSize < 1 SET @PageSize = 1;
+
+	DECLARE @sql NVARCHAR(MAX) = N'
+		SELECT id, code, size, chest_size, arm_length, body_length, created_at, updated_at, deleted_at
+		FROM mst_almamater_sizes
+		WHERE deleted_at IS NOT NULL
+		AND (@Filter = '''' OR code LIKE ''%'' + @Filter + ''%'' OR size LIKE ''%'' + @Filter + ''%'')
+		ORDER BY ' + QUOTENAME(@SortBy) + N' ' + @SortDirection + N'
+		OFFSET (@Page - 1) * @PageSize ROWS
+		FETCH NEXT @PageSize ROWS ONLY';
+
+	EXEC sp_executesql @sql,
+		N'@Filter NVARCHAR(255), @Page INT, @PageSize BIGINT',
+		@Filter, @Page, @PageSize;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_almamater_sizes_get_by_id
+	@id VARCHAR(36)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, code, size, chest_size, arm_length, body_length, created_at, updated_at
+	FROM mst_almamater_sizes
+	WHERE id = @id;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_almamater_sizes_insert
+	@id VARCHAR(36),
+	@code NVARCHAR(50),
+	@size NVARCHAR(255),
+	@chest_size NVARCHAR(255),
+	@arm_length NVARCHAR(255),
+	@body_length NVARCHAR(255),
+	@created_at BIGINT,
+	@created_by VARCHAR(36) = NULL,
+	@updated_at BIGINT,
+	@updated_by VARCHAR(36) = NULL
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	INSERT INTO mst_almamater_sizes (id, code, size, chest_size, arm_length, body_length, created_at, created_by, updated_at, updated_by)
+	VALUES (@id, @code, @size, @chest_size, @arm_length, @body_length, @created_at, @created_by, @updated_at, @updated_by);
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_almamater_sizes_update
+	@id VARCHAR(36),
+	@code NVARCHAR(50),
+	@size NVARCHAR(255),
+	@chest_size NVARCHAR(255),
+	@arm_length NVARCHAR(255),
+	@body_length NVARCHAR(255),
+	@updated_at BIGINT,
+	@updated_by VARCHAR(36) = NULL
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	UPDATE mst_almamater_sizes SET
+		code = @code,
+		size = @size,
+		chest_size = @chest_size,
+		arm_length = @arm_length,
+		body_length = @body_length,
+		updated_at = @updated_at,
+		updated_by = @updated_by
+	WHERE id = @id;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_almamater_sizes_delete
+	@id VARCHAR(36),
+	@deleted_at BIGINT,
+	@deleted_by VARCHAR(36) = NULL
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	UPDATE mst_almamater_sizes SET
+		deleted_at = @deleted_at,
+		deleted_by = @deleted_by
+	WHERE id = @id;
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_almamater_sizes_restore
+	@id VARCHAR(36)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	UPDATE mst_almamater_sizes SET
+		deleted_at = NULL,
+		deleted_by = NULL
+	WHERE id = @id;
+END
+GO
@@ -0,0 +1,23 @@
+DROP PROCEDURE IF EXISTS sp_mst_marriage_statuses_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_marriage_statuses_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_marriage_statuses_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_marriage_statuses_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_marriage_statuses_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_marriage_statuses_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_marriage_statuses_restore;
+GO
+
+DROP TABLE IF EXISTS mst_marriage_statuses;
+GO
@@ -0,0 +1,23 @@
+DROP PROCEDURE IF EXISTS sp_mst_banks_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_banks_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_banks_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_banks_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_banks_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_banks_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_banks_restore;
+GO
+
+DROP TABLE IF EXISTS mst_banks;
+GO
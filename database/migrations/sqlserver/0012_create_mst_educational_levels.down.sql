@@ -0,0 +1,23 @@
+DROP PROCEDURE IF EXISTS sp_mst_educational_levels_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educational_levels_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educational_levels_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educational_levels_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educational_levels_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educational_levels_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educational_levels_restore;
+GO
+
+DROP TABLE IF EXISTS mst_educational_levels;
+GO
@@ -0,0 +1,23 @@
+DROP PROCEDURE IF EXISTS sp_mst_unsia_study_programs_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_unsia_study_programs_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_unsia_study_programs_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_unsia_study_programs_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_unsia_study_programs_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_unsia_study_programs_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_unsia_study_programs_restore;
+GO
+
+DROP TABLE IF EXISTS mst_unsia_study_programs;
+GO
@@ -0,0 +1,26 @@
+DROP PROCEDURE IF EXISTS sp_mst_educations_get;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educations_has_deleted;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educations_get_by_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educations_get_by_education_level_id;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educations_insert;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educations_update;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educations_delete;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educations_restore;
+GO
+
+DROP TABLE IF EXISTS mst_educations;
+GO
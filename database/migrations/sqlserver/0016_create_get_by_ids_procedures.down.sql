@@ -0,0 +1,44 @@
+DROP PROCEDURE IF EXISTS sp_mst_countries_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_provinces_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_cities_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_districts_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_villages_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_religions_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_jobs_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_ethnics_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_almamater_sizes_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_marriage_statuses_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_banks_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educational_levels_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_study_programs_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_unsia_study_programs_get_by_ids;
+GO
+
+DROP PROCEDURE IF EXISTS sp_mst_educations_get_by_ids;
+GO
@@ -0,0 +1,181 @@
+-- sp_mst_*_get_by_ids resolve a page of relations in a single call
+
+CREATE OR ALTER PROCEDURE sp_mst_countries_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, name, phone_code, icon_flag_path, created_at, updated_at
+	FROM mst_countries
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_provinces_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, country_id, name, code, region_code, created_at, updated_at
+	FROM mst_provinces
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_cities_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, province_id, name, code, created_at, updated_at
+	FROM mst_cities
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_districts_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, city_id, name, code, created_at, updated_at
+	FROM mst_districts
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_villages_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, district_id, name, code, created_at, updated_at
+	FROM mst_villages
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_religions_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, code, name, created_at, updated_at
+	FROM mst_religions
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_jobs_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, code, name, description, created_at, updated_at
+	FROM mst_jobs
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_ethnics_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, name, region_of_origin, created_at, updated_at
+	FROM mst_ethnics
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_almamater_sizes_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, code, size, chest_size, arm_length, body_length, created_at, updated_at
+	FROM mst_almamater_sizes
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_marriage_statuses_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, name, created_at, updated_at
+	FROM mst_marriage_statuses
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_banks_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, code, name, created_at, updated_at
+	FROM mst_banks
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_educational_levels_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, code, name, description, created_at, updated_at
+	FROM mst_educational_levels
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_study_programs_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, name, created_at, updated_at
+	FROM mst_study_programs
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_unsia_study_programs_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, code, name, created_at, updated_at
+	FROM mst_unsia_study_programs
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
+
+CREATE OR ALTER PROCEDURE sp_mst_educations_get_by_ids
+	@ids NVARCHAR(MAX)
+AS
+BEGIN
+	SET NOCOUNT ON;
+
+	SELECT id, educational_level_id, study_program_id, name, created_at, updated_at
+	FROM mst_educations
+	WHERE id IN (SELECT value FROM STRING_SPLIT(@ids, ','));
+END
+GO
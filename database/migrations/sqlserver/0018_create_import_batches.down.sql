@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS import_batch_rows;
+GO
+
+DROP TABLE IF EXISTS import_batches;
+GO
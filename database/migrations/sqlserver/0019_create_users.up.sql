@@ -0,0 +1,28 @@
+-- users are the people who sign in to change reference data, user_sessions the refresh tokens issued at login
+
+CREATE TABLE users (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	username VARCHAR(100) NOT NULL,
+	name NVARCHAR(255) NOT NULL,
+	password_hash VARCHAR(100) NOT NULL,
+	active BIT NOT NULL,
+	created_at BIGINT NOT NULL,
+	updated_at BIGINT NOT NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_users_username ON users (username);
+GO
+
+CREATE TABLE user_sessions (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	user_id VARCHAR(36) NOT NULL,
+	refresh_hash VARCHAR(64) NOT NULL,
+	expires_at BIGINT NOT NULL,
+	created_at BIGINT NOT NULL,
+	revoked_at BIGINT NULL
+);
+GO
+
+CREATE INDEX ix_user_sessions_user_id ON user_sessions (user_id);
+GO
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS user_roles;
+GO
+
+DROP TABLE IF EXISTS role_permissions;
+GO
+
+DROP TABLE IF EXISTS roles;
+GO
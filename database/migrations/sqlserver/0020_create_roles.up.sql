@@ -0,0 +1,55 @@
+-- roles group permissions such as region.province:write, users get them through user_roles
+
+CREATE TABLE roles (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	name VARCHAR(100) NOT NULL,
+	description NVARCHAR(255) NULL,
+	created_at BIGINT NOT NULL,
+	updated_at BIGINT NOT NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_roles_name ON roles (name);
+GO
+
+CREATE TABLE role_permissions (
+	role_id VARCHAR(36) NOT NULL,
+	permission VARCHAR(150) NOT NULL,
+	PRIMARY KEY (role_id, permission)
+);
+GO
+
+CREATE TABLE user_roles (
+	user_id VARCHAR(36) NOT NULL,
+	role_id VARCHAR(36) NOT NULL,
+	PRIMARY KEY (user_id, role_id)
+);
+GO
+
+CREATE INDEX ix_user_roles_role_id ON user_roles (role_id);
+GO
+
+INSERT INTO roles (id, name, description, created_at, updated_at) VALUES
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c01', 'admin', 'Every permission', 0, 0),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c02', 'data_steward', 'Reads, writes, imports, the trash and import batches of every reference entity', 0, 0),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c03', 'editor', 'Reads and writes every reference entity', 0, 0),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c04', 'viewer', 'Reads every reference entity', 0, 0);
+GO
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c01', '*'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c02', 'region.*:*'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c02', 'biodata.*:*'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c02', 'education.*:*'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c02', 'admin.import:read'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c02', 'admin.import:revert'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c03', 'region.*:read'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c03', 'region.*:write'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c03', 'biodata.*:read'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c03', 'biodata.*:write'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c03', 'education.*:read'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c03', 'education.*:write'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c04', 'region.*:read'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c04', 'biodata.*:read'),
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c04', 'education.*:read');
+GO
@@ -0,0 +1,19 @@
+-- api_keys let services read and write reference data without a user, only the hash of a key is stored
+
+CREATE TABLE api_keys (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	name NVARCHAR(255) NOT NULL,
+	prefix VARCHAR(20) NOT NULL,
+	key_hash VARCHAR(64) NOT NULL,
+	scopes NVARCHAR(MAX) NOT NULL,
+	created_at BIGINT NOT NULL,
+	created_by VARCHAR(36) NULL,
+	updated_at BIGINT NOT NULL,
+	last_used_at BIGINT NULL,
+	expires_at BIGINT NULL,
+	revoked_at BIGINT NULL
+);
+GO
+
+CREATE UNIQUE INDEX ux_api_keys_key_hash ON api_keys (key_hash);
+GO
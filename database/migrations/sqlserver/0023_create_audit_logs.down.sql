@@ -0,0 +1,11 @@
+DELETE FROM user_roles WHERE role_id = '5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c05';
+GO
+
+DELETE FROM role_permissions WHERE role_id = '5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c05';
+GO
+
+DELETE FROM roles WHERE id = '5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c05';
+GO
+
+DROP TABLE IF EXISTS audit_logs;
+GO
@@ -0,0 +1,34 @@
+-- audit_logs records every change to the reference data, with its actor, source and field diff
+
+CREATE TABLE audit_logs (
+	id VARCHAR(36) NOT NULL PRIMARY KEY,
+	entity VARCHAR(100) NOT NULL,
+	table_name VARCHAR(100) NOT NULL,
+	record_id VARCHAR(36) NOT NULL,
+	action VARCHAR(10) NOT NULL,
+	source VARCHAR(10) NOT NULL,
+	batch_id VARCHAR(36) NULL,
+	actor_id VARCHAR(36) NULL,
+	actor_kind VARCHAR(10) NULL,
+	actor_name NVARCHAR(255) NULL,
+	changes NVARCHAR(MAX) NOT NULL,
+	created_at BIGINT NOT NULL
+);
+GO
+
+CREATE INDEX ix_audit_logs_created_at ON audit_logs (created_at);
+GO
+
+CREATE INDEX ix_audit_logs_table_name_record_id ON audit_logs (table_name, record_id);
+GO
+
+CREATE INDEX ix_audit_logs_actor_id ON audit_logs (actor_id);
+GO
+
+INSERT INTO roles (id, name, description, created_at, updated_at) VALUES
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c05', 'auditor', 'Reads and exports the audit log', 0, 0);
+GO
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+	('5d1c1f5e-2a4b-4c51-9a63-0b7f3e1d2c05', 'audit.log:read');
+GO
@@ -15,7 +15,7 @@ func SendSuccess(c *fiber.Ctx, statusCode int, data interface{}, message string)
 func SendFailed(c *fiber.Ctx, statusCode int, data interface{}, message string) error {
 	return c.Status(statusCode).JSON(fiber.Map{
 		"error":   true,
-		"data":    nil,
+		"data":    data,
 		"message": message,
 	})
 }
@@ -0,0 +1,41 @@
+package helpers
+
+import "context"
+
+/* Actor Kinds tell a signed in user from an API key */
+const (
+	ActorUser   = "user"
+	ActorAPIKey = "api_key"
+)
+
+/* Actor is the user or API key a request acts as, its ID is stored in the created_by, updated_by and deleted_by columns */
+type Actor struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type actorKey struct{}
+
+/* With Actor returns a copy of ctx carrying actor */
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+/* Actor From returns the actor carried by ctx, false when there is none */
+func ActorFrom(ctx context.Context) (Actor, bool) {
+	if ctx == nil {
+		return Actor{}, false
+	}
+	actor, found := ctx.Value(actorKey{}).(Actor)
+	return actor, found
+}
+
+/* Actor ID returns the id of the actor carried by ctx, nil when there is none so the column stays NULL */
+func ActorID(ctx context.Context) *string {
+	actor, found := ActorFrom(ctx)
+	if !found || actor.ID == "" {
+		return nil
+	}
+	return &actor.ID
+}
@@ -1,12 +1,17 @@
 package helpers
 
 import (
+	"context"
 	"data-referensi/config"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
 )
 
 /* Check ID Model Is Exist */
-func CheckModelIDExist(id string, model interface{}) (bool, error) {
-	db := config.DB
+func CheckModelIDExist(ctx context.Context, id string, model interface{}) (bool, error) {
+	db := config.DB.WithContext(ctx)
 	var count int64
 
 	err := db.Model(model).Where("id = ?", id).Count(&count).Error
@@ -17,8 +22,8 @@ func CheckModelIDExist(id string, model interface{}) (bool, error) {
 }
 
 /* Check Model Is Null Deleted At  */
-func CheckModelIsNullDeleted(id string, model interface{}) (bool, error) {
-	db := config.DB
+func CheckModelIsNullDeleted(ctx context.Context, id string, model interface{}) (bool, error) {
+	db := config.DB.WithContext(ctx)
 	var count int64
 
 	err := db.Model(model).Where("deleted_at IS NULL").Where("id = ?", id).Count(&count).Error
@@ -29,8 +34,8 @@ func CheckModelIsNullDeleted(id string, model interface{}) (bool, error) {
 }
 
 /* Check Model Is Not Null Deleted At  */
-func CheckModelIsNotNullDeleted(id string, model interface{}) (bool, error) {
-	db := config.DB
+func CheckModelIsNotNullDeleted(ctx context.Context, id string, model interface{}) (bool, error) {
+	db := config.DB.WithContext(ctx)
 	var count int64
 
 	err := db.Model(model).Where("deleted_at IS NOT NULL").Where("id = ?", id).Count(&count).Error
@@ -41,8 +46,8 @@ func CheckModelIsNotNullDeleted(id string, model interface{}) (bool, error) {
 }
 
 /* Check Model Is Not Found */
-func CheckModelIsNotFound(id string, model interface{}) error {
-	exist, err := CheckModelIsNullDeleted(id, model)
+func CheckModelIsNotFound(ctx context.Context, id string, model interface{}) error {
+	exist, err := CheckModelIsNullDeleted(ctx, id, model)
 	if err != nil {
 		return err
 	}
@@ -53,3 +58,13 @@ func CheckModelIsNotFound(id string, model interface{}) error {
 
 	return nil
 }
+
+/* Check Error Is Duplicate Key */
+func CheckDuplicateKey(err error) bool {
+	return errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "duplicate key row")
+}
+
+/* Check Error Is A Timeout Or Cancellation Of The Request Context */
+func CheckTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
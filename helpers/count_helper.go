@@ -1,9 +1,12 @@
 package helpers
 
-import "data-referensi/config"
+import (
+	"context"
+	"data-referensi/config"
+)
 
-func CountModelSize(model interface{}, nullableDeletedAt bool) int64 {
-	db := config.DB
+func CountModelSize(ctx context.Context, model interface{}, nullableDeletedAt bool) int64 {
+	db := config.DB.WithContext(ctx)
 	var count int64
 	var where string
 
@@ -0,0 +1,83 @@
+//go:build unix
+
+package helpers
+
+import (
+	"context"
+	"net"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+/*
+Watch Disconnect calls cancel as soon as the client resets conn, seen as an
+error when peeking at the socket. An end of stream alone may be a client that
+shut down its side after sending the request and still waits for the answer,
+so it is watched on for a reset. Watching stops when the client sends more
+data, a pipelined request, and when stop is called, which must be before the
+server reads from conn again. The socket is polled next to a pipe that wakes
+the watcher on stop, its read deadline is left to the server. Connections that
+are not plain sockets, such as TLS ones, are not watched.
+*/
+func WatchDisconnect(conn net.Conn, cancel context.CancelFunc) (stop func()) {
+	socket, ok := conn.(syscall.Conn)
+	if !ok {
+		return func() {}
+	}
+	raw, err := socket.SyscallConn()
+	if err != nil {
+		return func() {}
+	}
+	fd := -1
+	if err := raw.Control(func(descriptor uintptr) { fd = int(descriptor) }); err != nil {
+		return func() {}
+	}
+	wake := make([]int, 2)
+	if err := unix.Pipe(wake); err != nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watch(fd, wake[0], cancel)
+	}()
+
+	return func() {
+		unix.Write(wake[1], []byte{0})
+		<-done
+		unix.Close(wake[0])
+		unix.Close(wake[1])
+	}
+}
+
+func watch(fd int, wake int, cancel context.CancelFunc) {
+	peek := make([]byte, 1)
+	events := int16(unix.POLLIN)
+	for {
+		fds := []unix.PollFd{{Fd: int32(fd), Events: events}, {Fd: int32(wake), Events: unix.POLLIN}}
+		if _, err := unix.Poll(fds, -1); err != nil {
+			if err == unix.EINTR {
+				continue
+			}
+			return
+		}
+		if fds[1].Revents != 0 {
+			return
+		}
+
+		/* After an end of stream only an error or a hang up is polled for, the socket stays readable */
+		n, _, err := unix.Recvfrom(fd, peek, unix.MSG_PEEK|unix.MSG_DONTWAIT)
+		switch {
+		case err == unix.EAGAIN || err == unix.EWOULDBLOCK || err == unix.EINTR:
+		case err != nil || fds[0].Revents&(unix.POLLERR|unix.POLLHUP) != 0:
+			cancel()
+			return
+		case n == 0:
+			events = 0
+		default:
+			return
+		}
+	}
+}
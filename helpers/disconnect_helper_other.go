@@ -0,0 +1,13 @@
+//go:build !unix
+
+package helpers
+
+import (
+	"context"
+	"net"
+)
+
+/* Watch Disconnect does not watch conn where the socket cannot be peeked, the request is stopped by its deadline */
+func WatchDisconnect(conn net.Conn, cancel context.CancelFunc) (stop func()) {
+	return func() {}
+}
@@ -1,10 +1,12 @@
 package helpers
 
+import "context"
+
 /* Ensure UUID */
-func EnsureUUID(model interface{}) (string, error) {
+func EnsureUUID(ctx context.Context, model interface{}) (string, error) {
 	for {
 		id := GenerateUUID()
-		exists, err := CheckModelIDExist(id, model)
+		exists, err := CheckModelIDExist(ctx, id, model)
 		if err != nil {
 			return "", err
 		}
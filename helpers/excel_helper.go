@@ -1,20 +1,19 @@
 package helpers
 
-import (
-	"fmt"
+import "fmt"
 
-	"github.com/xuri/excelize/v2"
-)
-
-/* Auto Size Column Export Excel */
-func ExcelAutoSizeColumn(file *excelize.File, sheetName, col string, numRows int) {
-	var maxLength float64
-	for row := 1; row <= numRows+1; row++ {
-		cellValue, _ := file.GetCellValue(sheetName, fmt.Sprintf("%s%d", col, row))
-		cellLength := float64(len(cellValue))
-		if cellLength > maxLength {
-			maxLength = cellLength
+/* Column Widths Export Excel, sized to the longest value of each column */
+func ExcelColumnWidths(rows [][]interface{}) []float64 {
+	widths := []float64{}
+	for _, row := range rows {
+		for i, value := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if length := float64(len(fmt.Sprint(value)) + 2); length > widths[i] {
+				widths[i] = length
+			}
 		}
 	}
-	file.SetColWidth(sheetName, col, col, maxLength+2)
+	return widths
 }
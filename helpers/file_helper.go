@@ -0,0 +1,13 @@
+package helpers
+
+import "os"
+
+/* Temporary File is removed when the response body is closed */
+type TemporaryFile struct {
+	*os.File
+}
+
+func (f *TemporaryFile) Close() error {
+	f.File.Close()
+	return os.Remove(f.Name())
+}
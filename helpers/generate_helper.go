@@ -69,8 +69,49 @@ func GenerateRM(method string, typeMessage ...bool) string {
 			return "Data restore successful"
 		}
 		return "Data restore failed"
+	case "check":
+		if messageType {
+			return "Data check was successful"
+		}
+		return "Data check failed"
+	case "queue":
+		if messageType {
+			return "Job queued successfully"
+		}
+		return "The job queue is full, try again later"
+	case "cancel":
+		if messageType {
+			return "Job cancelled successfully"
+		}
+		return "The job has already finished"
+	case "revoke":
+		if messageType {
+			return "Revoked successfully"
+		}
+		return "Revoke failed"
+	case "revert":
+		if messageType {
+			return "Import reverted successfully"
+		}
+		return "The import has already been reverted"
+	case "login":
+		if messageType {
+			return "Signed in successfully"
+		}
+		return "Sign in failed"
+	case "logout":
+		if messageType {
+			return "Signed out successfully"
+		}
+		return "Sign out failed"
+	case "unauthorized":
+		return "A valid access token or API key is required"
+	case "forbidden":
+		return "Missing permission"
 	case "exist":
 		return "Data already exists"
+	case "timeout":
+		return "The database did not respond in time"
 	case "save":
 		if messageType {
 			return "Save data successfully"
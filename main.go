@@ -1,18 +1,35 @@
 package main
 
 import (
+	"context"
+	"data-referensi/app/jobs"
 	"data-referensi/app/middlewares"
+	"data-referensi/app/models"
+	"data-referensi/commands"
 	"data-referensi/config"
 	"data-referensi/routes"
+	"log"
+	"os"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := commands.Run(os.Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	app := fiber.New()
 
 	config.ConnectDB()
 
+	verifySchema()
+
+	jobs.Start(config.JobWorkers, config.JobQueue, config.JobRetention)
+
 	app.Use(middlewares.CleanupMiddleware())
 
 	routes.SetupRouter(app)
@@ -21,3 +38,44 @@ func main() {
 
 	app.Listen(":3000")
 }
+
+/* Verify Schema logs every table and procedure that drifted, and refuses to start in strict mode */
+func verifySchema() {
+	if config.SchemaCheck == config.SchemaCheckOff {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout(config.OperationList))
+	defer cancel()
+
+	report := models.VerifySchema(ctx)
+	if report.Valid {
+		log.Println("Database schema verified successfully!")
+		return
+	}
+
+	for _, table := range report.Tables {
+		if !table.Exists {
+			log.Printf("Schema drift: table %s is missing", table.Name)
+		} else if len(table.MissingColumns) > 0 {
+			log.Printf("Schema drift: table %s is missing columns %v", table.Name, table.MissingColumns)
+		}
+	}
+	for _, procedure := range report.Procedures {
+		if !procedure.Exists {
+			log.Printf("Schema drift: procedure %s is missing", procedure.Name)
+			continue
+		}
+		if len(procedure.MissingParameters) > 0 {
+			log.Printf("Schema drift: procedure %s is missing parameters %v", procedure.Name, procedure.MissingParameters)
+		}
+		if len(procedure.UnexpectedParameters) > 0 {
+			log.Printf("Schema drift: procedure %s has unexpected parameters %v", procedure.Name, procedure.UnexpectedParameters)
+		}
+	}
+
+	if config.SchemaCheck == config.SchemaCheckStrict {
+		log.Fatal("Database schema does not match the models, run `migrate up` or see GET /api/admin/schema")
+	}
+	log.Println("Database schema drifted, see GET /api/admin/schema for the full report")
+}
@@ -0,0 +1,47 @@
+package routes
+
+import (
+	controllers "data-referensi/app/controllers/admin"
+	"data-referensi/app/middlewares"
+	"data-referensi/app/requests"
+	"data-referensi/config"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+/* Admin Route registers the admin routes, each needs its `admin.<entity>:<action>` permission */
+func AdminRoute(app fiber.Router) {
+	admin := app.Group("/admin")
+
+	list := middlewares.TimeoutMiddleware(config.OperationList)
+	write := middlewares.TimeoutMiddleware(config.OperationWrite)
+	canRead := middlewares.PermissionMiddleware("read")
+	canWrite := middlewares.PermissionMiddleware("write")
+
+	/* Schema */
+	admin.Get("/schema", canRead, list, controllers.GetSchemaReport)
+
+	/* Import Batches */
+	admin.Get("/imports", canRead, list, controllers.GetImportBatches)
+	admin.Get("/imports/:id", canRead, list, controllers.GetImportBatch)
+	admin.Post("/imports/:id/revert", middlewares.PermissionMiddleware("revert"), middlewares.TimeoutMiddleware(config.OperationImport), controllers.RevertImportBatch)
+
+	/* Roles */
+	admin.Get("/roles", canRead, list, controllers.GetRoles)
+	admin.Get("/roles/:id", canRead, list, controllers.GetRole)
+	admin.Post("/roles", canWrite, write, requests.ValidateBody[requests.RoleRequest], controllers.CreateRole)
+	admin.Put("/roles/:id", canWrite, write, requests.ValidateBody[requests.RoleRequest], controllers.UpdateRole)
+	admin.Delete("/roles/:id", canWrite, write, controllers.DeleteRole)
+
+	/* Users */
+	admin.Get("/users", canRead, list, controllers.GetUsers)
+	admin.Put("/users/:id/roles", canWrite, write, requests.ValidateBody[requests.UserRolesRequest], controllers.SetUserRoles)
+
+	/* API Keys, issued, changed and revoked by users only so no key manages another */
+	byUser := middlewares.UserMiddleware()
+	admin.Get("/api-keys", canRead, list, controllers.GetAPIKeys)
+	admin.Get("/api-keys/:id", canRead, list, controllers.GetAPIKey)
+	admin.Post("/api-keys", byUser, canWrite, write, requests.ValidateBody[requests.APIKeyRequest], controllers.CreateAPIKey)
+	admin.Put("/api-keys/:id", byUser, canWrite, write, requests.ValidateBody[requests.APIKeyRequest], controllers.UpdateAPIKey)
+	admin.Delete("/api-keys/:id", byUser, canWrite, write, controllers.RevokeAPIKey)
+}
@@ -6,7 +6,11 @@ import (
 
 func SetupRouter(app *fiber.App) {
 	api := app.Group("/api")
+	UserRoute(api)
 	RegionRoute(api)
 	BiodataRoute(api)
 	EducationRoute(api)
+	AdminRoute(api)
+	AuditRoute(api)
+	JobRoute(api)
 }
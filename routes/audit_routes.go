@@ -0,0 +1,19 @@
+package routes
+
+import (
+	controllers "data-referensi/app/controllers/audit"
+	"data-referensi/app/middlewares"
+	"data-referensi/config"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+/* Audit Route registers the list and export of the audit log, both need `audit.log:read` */
+func AuditRoute(app fiber.Router) {
+	audit := app.Group("/audit")
+
+	canRead := middlewares.ResourcePermissionMiddleware("audit.log", "read")
+
+	audit.Get("/", canRead, middlewares.TimeoutMiddleware(config.OperationList), controllers.GetAuditLogs)
+	audit.Get("/export", canRead, middlewares.TimeoutMiddleware(config.OperationExport), controllers.ExportAuditLogs)
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	controllers "data-referensi/app/controllers/job"
+	"data-referensi/app/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+/* Job Route registers the status, cancel and download routes of background jobs */
+func JobRoute(app fiber.Router) {
+	job := app.Group("/jobs")
+
+	/* Only the creator of a job or a caller with the permission of its route may reach it */
+	access := middlewares.JobMiddleware()
+
+	job.Get("/:id", access, controllers.GetJob)
+	job.Get("/:id/download", access, controllers.DownloadJob)
+	job.Delete("/:id", access, controllers.CancelJob)
+}
@@ -0,0 +1,61 @@
+package routes
+
+import (
+	controllers "data-referensi/app/controllers/reference"
+	"data-referensi/app/middlewares"
+	"data-referensi/app/models"
+	"data-referensi/app/requests"
+	"data-referensi/config"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+/*
+Reference Route registers the list, detail, write, import/export and trash
+routes of an entity. Reads are public unless PUBLIC_READS is off, the other
+routes need the permission of their action, `write`, `import`, `trash` or
+`restore`, on the entity of the route. An export of the trash needs `trash`.
+*/
+func ReferenceRoute[T any, S any, R any](app fiber.Router, path string, entity *models.Entity[T, S, R]) fiber.Router {
+	controller := controllers.New(entity)
+
+	list := middlewares.TimeoutMiddleware(config.OperationList)
+	write := middlewares.TimeoutMiddleware(config.OperationWrite)
+	export := middlewares.TimeoutMiddleware(config.OperationExport)
+	imports := middlewares.TimeoutMiddleware(config.OperationImport)
+
+	read := middlewares.ReadMiddleware()
+	canWrite := middlewares.PermissionMiddleware("write")
+
+	group := app.Group(path)
+	trash := group.Group("trashs")
+	trash.Get("/", middlewares.PermissionMiddleware("trash"), list, requests.ValidatePagination, controller.GetTrash)
+	trash.Put("/:id", middlewares.PermissionMiddleware("restore"), write, controller.Restore)
+
+	group.Get("/", read, list, requests.ValidatePagination, controller.Get)
+	group.Get("/export", middlewares.ExportMiddleware(), export, controller.Export)
+	group.Get("/import-template", read, export, controller.ImportTemplate)
+	group.Get("/search", read, list, controller.Search)
+	if entity.Parent != nil {
+		group.Get(fmt.Sprintf("/%s/:%s", entity.Parent.Route, entity.Parent.Column), read, list, controller.GetByParent)
+	}
+	group.Get("/:id", read, list, controller.Find)
+	group.Post("/", canWrite, write, requests.ValidateBody[R], controller.Create)
+	group.Post("/import", middlewares.PermissionMiddleware("import"), imports, controller.Import)
+	group.Put("/:id", canWrite, write, requests.ValidateBody[R], controller.Update)
+	group.Delete("/:id", canWrite, write, controller.Delete)
+
+	return group
+}
+
+/* Hierarchy Route registers the multi-sheet export and import of a hierarchy, under the permissions of `<group>.hierarchy` */
+func HierarchyRoute(app fiber.Router, path string, hierarchy *models.Hierarchy) fiber.Router {
+	controller := controllers.NewHierarchy(hierarchy)
+
+	group := app.Group(path)
+	group.Get("/export", middlewares.ReadMiddleware(), middlewares.TimeoutMiddleware(config.OperationExport), controller.Export)
+	group.Post("/import", middlewares.PermissionMiddleware("import"), middlewares.TimeoutMiddleware(config.OperationImport), controller.Import)
+
+	return group
+}
@@ -1,13 +1,22 @@
 package routes
 
-import "github.com/gofiber/fiber/v2"
+import (
+	controllers "data-referensi/app/controllers/user"
+	"data-referensi/app/middlewares"
+	"data-referensi/app/requests"
+	"data-referensi/config"
+
+	"github.com/gofiber/fiber/v2"
+)
 
 func UserRoute(app fiber.Router) {
 	user := app.Group("/users")
-	user.Post("/login", func(c *fiber.Ctx) error {
-		return c.SendString("Login")
-	})
-	user.Post("/logout", func(c *fiber.Ctx) error {
-		return c.SendString("Logout")
-	})
+
+	write := middlewares.TimeoutMiddleware(config.OperationWrite)
+	auth := middlewares.UserMiddleware()
+
+	user.Post("/login", write, requests.ValidateBody[requests.LoginRequest], controllers.Login)
+	user.Post("/refresh", write, requests.ValidateBody[requests.RefreshRequest], controllers.Refresh)
+	user.Post("/logout", auth, write, controllers.Logout)
+	user.Get("/me", auth, controllers.Me)
 }